@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"skald/internal/validation"
+	"skald/pkg/skald"
+	"skald/pkg/skald/batch"
+	"skald/pkg/skald/transcriber"
+)
+
+// runBatch implements "skald batch [flags] <dir>" and returns the exit code
+func runBatch(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	modelPath := fs.String("model", defaultModelPath, "Path to whisper model")
+	language := fs.String("language", "auto", "Language code (e.g., en, es, auto)")
+	workers := fs.Int("workers", 1, "Files transcribed at once; each worker loads its own copy of the model")
+	overwrite := fs.Bool("overwrite", false, "Replace existing .txt transcripts instead of skipping their audio files")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: skald batch [flags] <dir>")
+		fmt.Fprintln(stderr, "Transcribes every .wav/.raw file under <dir>, writing a .txt next to each.")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	dir := fs.Arg(0)
+	if *workers < 1 {
+		fmt.Fprintf(stderr, "Invalid configuration: workers must be at least 1, got %d\n", *workers)
+		return 1
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Fprintf(stderr, "Invalid batch directory: %s\n", dir)
+		return 1
+	}
+
+	validatedModelPath, err := validation.ValidateModelPath(*modelPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid model path: %v\n", err)
+		return 1
+	}
+
+	whisperTranscriber, err := transcriber.NewWhisper(validatedModelPath, *language)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to create transcriber: %v\n", err)
+		return 1
+	}
+	defer whisperTranscriber.Close()
+
+	// Whisper cannot run two transcriptions on one model, so each extra
+	// worker loads its own copy
+	summary, err := batch.RunWithOptions(dir, whisperTranscriber, batch.Options{
+		Workers:   *workers,
+		Overwrite: *overwrite,
+		NewTranscriber: func() (skald.Transcriber, error) {
+			return transcriber.NewWhisper(validatedModelPath, *language)
+		},
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Batch failed: %v\n", err)
+		return 1
+	}
+
+	printBatchSummary(stdout, summary)
+	if summary.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printBatchSummary reports each file's outcome followed by the totals
+func printBatchSummary(w io.Writer, summary batch.Summary) {
+	for _, result := range summary.Results {
+		switch {
+		case result.Err != nil:
+			fmt.Fprintf(w, "FAIL %s: %v\n", result.Path, result.Err)
+		case result.Skipped:
+			fmt.Fprintf(w, "SKIP %s: %s already exists\n", result.Path, result.OutputPath)
+		default:
+			fmt.Fprintf(w, "OK   %s -> %s\n", result.Path, result.OutputPath)
+		}
+	}
+	fmt.Fprintf(w, "%d succeeded, %d failed", summary.Succeeded, summary.Failed)
+	if summary.Skipped > 0 {
+		fmt.Fprintf(w, ", %d skipped (use -overwrite to replace)", summary.Skipped)
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"skald/pkg/skald/batch"
+)
+
+func TestRunBatch_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := runBatch(nil, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected exit code 2 without a directory, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Usage: skald batch") {
+		t.Errorf("Expected usage message, got %q", stderr.String())
+	}
+}
+
+func TestRunBatch_InvalidDirectory(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := runBatch([]string{"/non/existent/dir"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1 for missing directory, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Invalid batch directory") {
+		t.Errorf("Expected directory error, got %q", stderr.String())
+	}
+}
+
+func TestRunBatch_InvalidWorkers(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := runBatch([]string{"-workers", "0", t.TempDir()}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1 for zero workers, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "workers must be at least 1") {
+		t.Errorf("Expected workers error, got %q", stderr.String())
+	}
+}
+
+func TestRunBatch_InvalidModel(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := runBatch([]string{"-model", "/non/existent/model.bin", t.TempDir()}, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("Expected exit code 1 for missing model, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Invalid model path") {
+		t.Errorf("Expected model error, got %q", stderr.String())
+	}
+}
+
+func TestPrintBatchSummary(t *testing.T) {
+	var out bytes.Buffer
+	printBatchSummary(&out, batch.Summary{
+		Results: []batch.Result{
+			{Path: "a.wav", OutputPath: "a.txt"},
+			{Path: "b.wav", OutputPath: "b.txt", Err: errors.New("bad header")},
+		},
+		Succeeded: 1,
+		Failed:    1,
+	})
+
+	expected := "OK   a.wav -> a.txt\nFAIL b.wav: bad header\n1 succeeded, 1 failed\n"
+	if out.String() != expected {
+		t.Errorf("printBatchSummary() = %q, want %q", out.String(), expected)
+	}
+
+	out.Reset()
+	printBatchSummary(&out, batch.Summary{
+		Results: []batch.Result{{Path: "c.wav", OutputPath: "c.txt", Skipped: true}},
+		Skipped: 1,
+	})
+	expected = "SKIP c.wav: c.txt already exists\n0 succeeded, 0 failed, 1 skipped (use -overwrite to replace)\n"
+	if out.String() != expected {
+		t.Errorf("printBatchSummary() = %q, want %q", out.String(), expected)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"skald/pkg/skald"
+	"skald/pkg/skald/audio"
+)
+
+// defaultCalibrationDuration is how long "skald calibrate" listens to the room
+const defaultCalibrationDuration = 3 * time.Second
+
+// runCalibrate implements "skald calibrate [flags]" and returns the exit code
+func runCalibrate(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("calibrate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	sampleRate := fs.Int("sample-rate", defaultSampleRate, "Audio sample rate")
+	duration := fs.Duration("duration", defaultCalibrationDuration, "How long to record background noise")
+	margin := fs.Float64("margin", audio.DefaultCalibrationMargin, "Multiple of the measured noise level used as the threshold")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: skald calibrate [flags]")
+		fmt.Fprintln(stderr, "Records background noise while you stay quiet and suggests a -silence-threshold.")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+	if err := validateSampleRate(*sampleRate); err != nil {
+		fmt.Fprintf(stderr, "Invalid configuration: %v\n", err)
+		return 1
+	}
+	if *duration <= 0 {
+		fmt.Fprintf(stderr, "Invalid configuration: duration must be positive, got %v\n", *duration)
+		return 1
+	}
+	if *margin < 1 {
+		fmt.Fprintf(stderr, "Invalid configuration: margin must be at least 1, got %v\n", *margin)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "Stay quiet for %v while background noise is measured...\n", *duration)
+	// Note: Safe conversion after validation - sampleRate already checked to be within uint32 range
+	capture := audio.NewCapture(uint32(*sampleRate)) //nolint:gosec
+	noise, err := recordAmbient(context.Background(), capture, *duration)
+	if err != nil {
+		fmt.Fprintf(stderr, "Calibration failed: %v\n", err)
+		return 1
+	}
+
+	detector := audio.NewSilenceDetector()
+	fmt.Fprintf(stdout, "Background noise level: %.4f RMS\n", detector.CalculateRMS(noise))
+	fmt.Fprintf(stdout, "Suggested setting: -silence-threshold %.4f\n", detector.SuggestThreshold(noise, float32(*margin)))
+	return 0
+}
+
+// recordAmbient collects audio from capture for duration, or until the
+// capture ends its stream
+func recordAmbient(ctx context.Context, capture skald.AudioCapture, duration time.Duration) ([]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	frames, err := capture.Start(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start audio capture: %w", err)
+	}
+	defer capture.Stop()
+
+	var samples []float32
+	for {
+		select {
+		case <-ctx.Done():
+			return checkRecorded(samples)
+		case frame, ok := <-frames:
+			if !ok {
+				return checkRecorded(samples)
+			}
+			samples = append(samples, frame...)
+		}
+	}
+}
+
+// checkRecorded rejects an empty recording, which would measure as silence
+func checkRecorded(samples []float32) ([]float32, error) {
+	if len(samples) == 0 {
+		return nil, errors.New("no audio received from capture device")
+	}
+	return samples, nil
+}
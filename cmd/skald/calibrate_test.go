@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"skald/pkg/skald/mocks"
+)
+
+func TestRunCalibrate_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		code int
+		want string
+	}{
+		{"unexpected argument", []string{"extra"}, 2, "Usage: skald calibrate"},
+		{"sample rate too low", []string{"-sample-rate", "100"}, 1, "sample rate too low"},
+		{"zero duration", []string{"-duration", "0s"}, 1, "duration must be positive"},
+		{"margin below one", []string{"-margin", "0.5"}, 1, "margin must be at least 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runCalibrate(tt.args, &stdout, &stderr); code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("Expected stderr containing %q, got %q", tt.want, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRecordAmbient(t *testing.T) {
+	capture := &mocks.MockAudioCapture{StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+		ch := make(chan []float32, 2)
+		ch <- []float32{0.01, -0.01}
+		ch <- []float32{0.02}
+		close(ch)
+		return ch, nil
+	}}
+
+	samples, err := recordAmbient(context.Background(), capture, time.Second)
+	if err != nil {
+		t.Fatalf("recordAmbient() error = %v", err)
+	}
+	if len(samples) != 3 {
+		t.Errorf("Expected 3 samples, got %d", len(samples))
+	}
+	if capture.StopCalled != 1 {
+		t.Errorf("Expected capture to be stopped once, got %d", capture.StopCalled)
+	}
+}
+
+func TestRecordAmbient_Errors(t *testing.T) {
+	t.Run("start fails", func(t *testing.T) {
+		capture := &mocks.MockAudioCapture{StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+			return nil, errors.New("no device")
+		}}
+		if _, err := recordAmbient(context.Background(), capture, time.Second); err == nil {
+			t.Error("Expected error when capture fails to start")
+		}
+	})
+
+	t.Run("no audio before timeout", func(t *testing.T) {
+		if _, err := recordAmbient(context.Background(), &mocks.MockAudioCapture{}, 10*time.Millisecond); err == nil {
+			t.Error("Expected error when no audio is received")
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+
+	"skald/internal/validation"
+	"skald/pkg/skald/audio"
+	"skald/pkg/skald/backend"
+	"skald/pkg/skald/output"
+	"skald/pkg/skald/transcriber"
+)
+
+// serviceDialTimeout limits how long -check waits to reach a transcription service
+const serviceDialTimeout = 5 * time.Second
+
+// checkItem is a single setup check reported by -check
+type checkItem struct {
+	name     string
+	critical bool
+	run      func() error
+}
+
+// setupChecks returns the checks run by -check. The model file is only
+// checked for the whisper backend and the service URL only for the http
+// backend. The clipboard check is only included when clipboard output is
+// enabled and is never critical, since transcriptions are still printed to
+// stdout without it.
+func setupChecks(configErr error, backendName, modelPath, serviceURL string, sampleRate uint32, captureFormat string, clipboard bool) []checkItem {
+	checks := []checkItem{
+		{name: "Configuration", critical: true, run: func() error { return configErr }},
+	}
+	switch backendName {
+	case backend.Default:
+		checks = append(checks, checkItem{name: "Model file", critical: true, run: func() error {
+			_, err := validation.ValidateModelPath(modelPath)
+			return err
+		}})
+	case backend.HTTP:
+		checks = append(checks, checkItem{name: "Transcription service", critical: true, run: func() error {
+			return checkService(serviceURL)
+		}})
+	}
+	checks = append(checks, checkItem{name: "Audio device", critical: true, run: func() error {
+		if configErr != nil {
+			return fmt.Errorf("skipped due to invalid configuration")
+		}
+		return checkAudioDevice(sampleRate, captureFormat)
+	}})
+	if clipboard {
+		checks = append(checks, checkItem{name: "Clipboard (" + output.ClipboardTool() + ")", run: output.CheckClipboard})
+	}
+	return checks
+}
+
+// checkAudioDevice opens the default capture device in format and closes it again
+func checkAudioDevice(sampleRate uint32, format string) error {
+	capture := audio.NewCapture(sampleRate)
+	if err := capture.SetSampleFormat(format); err != nil {
+		return err
+	}
+	if _, err := capture.Start(context.Background()); err != nil {
+		return err
+	}
+	return capture.Stop()
+}
+
+// checkService checks that endpoint is a valid service URL and that its
+// host accepts connections. Nothing is sent, since the service may charge
+// for or log each request.
+func checkService(endpoint string) error {
+	if _, err := transcriber.NewHTTPTranscriber(endpoint, "", 0); err != nil {
+		return err
+	}
+	parsed, _ := url.Parse(endpoint)
+	port := parsed.Port()
+	if port == "" {
+		port = "80"
+		if parsed.Scheme == "https" {
+			port = "443"
+		}
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(parsed.Hostname(), port), serviceDialTimeout)
+	if err != nil {
+		return fmt.Errorf("cannot reach %s: %w", parsed.Host, err)
+	}
+	return conn.Close()
+}
+
+// runChecks prints a PASS/FAIL/WARN line per check and returns the exit
+// code: non-zero if any critical check failed.
+func runChecks(w io.Writer, checks []checkItem) int {
+	failed := 0
+	for _, check := range checks {
+		err := check.run()
+		switch {
+		case err == nil:
+			fmt.Fprintf(w, "PASS %s\n", check.name)
+		case check.critical:
+			failed++
+			fmt.Fprintf(w, "FAIL %s: %v\n", check.name, err)
+		default:
+			fmt.Fprintf(w, "WARN %s: %v\n", check.name, err)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(w, "%d critical check(s) failed\n", failed)
+		return 1
+	}
+	fmt.Fprintln(w, "All critical checks passed")
+	return 0
+}
+
+// validateOptions checks flag values that do not depend on external resources
+func validateOptions(sampleRate, startRetries, captureBuffer int, captureFormat, outputFormat, clipboardMode, outputSeparator string) error {
+	if err := validateSampleRate(sampleRate); err != nil {
+		return err
+	}
+	if err := audio.ValidateCaptureFormat(captureFormat); err != nil {
+		return err
+	}
+	if startRetries < 0 {
+		return fmt.Errorf("invalid start retries: %d (must be >= 0)", startRetries)
+	}
+	if captureBuffer < 1 {
+		return fmt.Errorf("invalid capture buffer: %d (must be >= 1)", captureBuffer)
+	}
+	if outputFormat != "text" && outputFormat != "json" {
+		return fmt.Errorf("invalid output format: %q (valid: text, json)", outputFormat)
+	}
+	if clipboardMode != "replace" && clipboardMode != "append" {
+		return fmt.Errorf("invalid clipboard mode: %q (valid: replace, append)", clipboardMode)
+	}
+	if _, err := output.ParseLineEnd(outputSeparator); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestRunChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		checks   []checkItem
+		wantCode int
+		want     []string
+	}{
+		{
+			name: "all pass",
+			checks: []checkItem{
+				{name: "Model file", critical: true, run: func() error { return nil }},
+			},
+			wantCode: 0,
+			want:     []string{"PASS Model file", "All critical checks passed"},
+		},
+		{
+			name: "non-critical failure only warns",
+			checks: []checkItem{
+				{name: "Model file", critical: true, run: func() error { return nil }},
+				{name: "Clipboard (xclip)", run: func() error { return errors.New("xclip not found") }},
+			},
+			wantCode: 0,
+			want:     []string{"WARN Clipboard (xclip): xclip not found", "All critical checks passed"},
+		},
+		{
+			name: "critical failure",
+			checks: []checkItem{
+				{name: "Audio device", critical: true, run: func() error { return errors.New("no device") }},
+				{name: "Model file", critical: true, run: func() error { return nil }},
+			},
+			wantCode: 1,
+			want:     []string{"FAIL Audio device: no device", "PASS Model file", "1 critical check(s) failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if code := runChecks(&buf, tt.checks); code != tt.wantCode {
+				t.Errorf("runChecks() = %d, want %d", code, tt.wantCode)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestSetupChecks_InvalidConfiguration(t *testing.T) {
+	configErr := errors.New("invalid output format")
+	checks := setupChecks(configErr, "whisper", "/nonexistent/model.bin", "", 16000, "f32le", false)
+
+	var buf bytes.Buffer
+	if code := runChecks(&buf, checks); code != 1 {
+		t.Errorf("runChecks() = %d, want 1", code)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"FAIL Configuration: invalid output format",
+		"FAIL Model file",
+		"FAIL Audio device: skipped due to invalid configuration",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Clipboard") {
+		t.Errorf("Clipboard check should be omitted when disabled:\n%s", out)
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		sampleRate    int
+		startRetries  int
+		captureBuffer int
+		captureFormat string
+		outputFormat  string
+		clipboardMode string
+		separator     string
+		wantErr       string
+	}{
+		{"valid", 16000, 3, 100, "s16le", "json", "append", "space", ""},
+		{"bad sample rate", 100, 3, 100, "f32le", "text", "replace", "newline", "sample rate too low"},
+		{"negative retries", 16000, -1, 100, "f32le", "text", "replace", "newline", "invalid start retries"},
+		{"zero capture buffer", 16000, 3, 0, "f32le", "text", "replace", "newline", "invalid capture buffer"},
+		{"bad capture format", 16000, 0, 100, "u8", "text", "replace", "newline", "unsupported capture format"},
+		{"bad output format", 16000, 0, 100, "f32le", "xml", "replace", "newline", "invalid output format"},
+		{"bad clipboard mode", 16000, 0, 100, "f32le", "text", "prepend", "newline", "invalid clipboard mode"},
+		{"bad output separator", 16000, 0, 100, "f32le", "text", "replace", "tab", "invalid output separator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOptions(tt.sampleRate, tt.startRetries, tt.captureBuffer, tt.captureFormat, tt.outputFormat, tt.clipboardMode, tt.separator)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateOptions() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateOptions() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetupChecks_HTTPBackend(t *testing.T) {
+	checks := setupChecks(errors.New("invalid output format"), "http", "/nonexistent/model.bin", "http://localhost:1/v1", 16000, "f32le", false)
+
+	var buf bytes.Buffer
+	runChecks(&buf, checks)
+	out := buf.String()
+	if strings.Contains(out, "Model file") {
+		t.Errorf("Model file should not be checked for the http backend:\n%s", out)
+	}
+	if !strings.Contains(out, "FAIL Transcription service") {
+		t.Errorf("Output missing service check:\n%s", out)
+	}
+}
+
+func TestCheckService(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{"reachable", "http://" + listener.Addr().String() + "/v1/transcribe", ""},
+		{"missing URL", "", "invalid transcription service URL"},
+		{"not http", "ftp://example.com", "invalid transcription service URL"},
+		{"unreachable", "http://127.0.0.1:1/", "cannot reach"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkService(tt.url)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkService() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkService() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"skald/internal/eval"
+	"skald/internal/validation"
+	"skald/pkg/skald/transcriber"
+)
+
+// runEval implements "skald eval [flags] <dir>" and returns the exit code
+func runEval(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("eval", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	modelPath := fs.String("model", defaultModelPath, "Path to whisper model")
+	language := fs.String("language", "auto", "Language code (e.g., en, es, auto)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: skald eval [flags] <dir>")
+		fmt.Fprintln(stderr, "Transcribes every .wav/.raw file under <dir> and reports the word error rate")
+		fmt.Fprintln(stderr, "against the reference transcript in the .txt file next to each.")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	dir := fs.Arg(0)
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Fprintf(stderr, "Invalid eval directory: %s\n", dir)
+		return 1
+	}
+
+	validatedModelPath, err := validation.ValidateModelPath(*modelPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid model path: %v\n", err)
+		return 1
+	}
+
+	whisperTranscriber, err := transcriber.NewWhisper(validatedModelPath, *language)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to create transcriber: %v\n", err)
+		return 1
+	}
+	defer whisperTranscriber.Close()
+
+	results, total, err := eval.Run(dir, whisperTranscriber)
+	if err != nil {
+		fmt.Fprintf(stderr, "Eval failed: %v\n", err)
+		return 1
+	}
+
+	if printEvalReport(stdout, results, total) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printEvalReport prints each file's word error rate followed by the overall
+// rate, and returns the number of files that failed to transcribe. Files
+// without a reference transcript are listed but do not count as failures.
+func printEvalReport(w io.Writer, results []eval.FileResult, total eval.Score) int {
+	failed, scored := 0, 0
+	for _, result := range results {
+		switch {
+		case errors.Is(result.Err, eval.ErrNoReference):
+			fmt.Fprintf(w, "SKIP %s: %v\n", result.Path, result.Err)
+		case result.Err != nil:
+			failed++
+			fmt.Fprintf(w, "FAIL %s: %v\n", result.Path, result.Err)
+		default:
+			scored++
+			fmt.Fprintf(w, "WER  %s: %.1f%% (%d/%d)\n", result.Path, result.Score.WER()*100, result.Score.Edits, result.Score.Words)
+		}
+	}
+	fmt.Fprintf(w, "Overall WER: %.1f%% (%d edits, %d words, %d files)\n", total.WER()*100, total.Edits, total.Words, scored)
+	return failed
+}
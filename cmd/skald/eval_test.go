@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"skald/internal/eval"
+)
+
+func TestRunEval_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := runEval(nil, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected exit code 2 without a directory, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Usage: skald eval") {
+		t.Errorf("Expected usage message, got %q", stderr.String())
+	}
+}
+
+func TestRunEval_InvalidDirectory(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	if code := runEval([]string{"/non/existent/dir"}, &stdout, &stderr); code != 1 {
+		t.Errorf("Expected exit code 1 for missing directory, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Invalid eval directory") {
+		t.Errorf("Expected directory error, got %q", stderr.String())
+	}
+}
+
+func TestRunEval_InvalidModel(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := runEval([]string{"-model", "/non/existent/model.bin", t.TempDir()}, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("Expected exit code 1 for missing model, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "Invalid model path") {
+		t.Errorf("Expected model error, got %q", stderr.String())
+	}
+}
+
+func TestPrintEvalReport(t *testing.T) {
+	var out bytes.Buffer
+	failed := printEvalReport(&out, []eval.FileResult{
+		{Path: "a.wav", Score: eval.Score{Edits: 1, Words: 4}},
+		{Path: "b.wav", Err: eval.ErrNoReference},
+		{Path: "c.wav", Err: errors.New("bad header")},
+	}, eval.Score{Edits: 1, Words: 4})
+
+	if failed != 1 {
+		t.Errorf("Expected 1 failure, got %d", failed)
+	}
+	expected := "WER  a.wav: 25.0% (1/4)\n" +
+		"SKIP b.wav: no reference transcript\n" +
+		"FAIL c.wav: bad header\n" +
+		"Overall WER: 25.0% (1 edits, 4 words, 1 files)\n"
+	if out.String() != expected {
+		t.Errorf("printEvalReport() = %q, want %q", out.String(), expected)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"skald/pkg/skald/audio"
+)
+
+// newInputCapture returns a capture reading raw samples from path, or from
+// stdin when path is "-". A file stays open until the process exits.
+func newInputCapture(path, format string, sampleRate uint32) (*audio.ReaderCapture, error) {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open input: %w", err)
+		}
+		reader = file
+	}
+	return audio.NewReaderCapture(reader, format, sampleRate)
+}
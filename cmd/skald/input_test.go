@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewInputCapture(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audio.raw")
+	if err := os.WriteFile(path, []byte{0, 0, 0, 0x3f}, 0600); err != nil { // 0.5 as f32le
+		t.Fatal(err)
+	}
+
+	capture, err := newInputCapture(path, "f32le", 16000)
+	if err != nil {
+		t.Fatalf("newInputCapture() error = %v", err)
+	}
+	audioChan, err := capture.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	frame := <-audioChan
+	if len(frame) != 1 || frame[0] != 0.5 {
+		t.Errorf("Frame = %v, want [0.5]", frame)
+	}
+	if _, ok := <-audioChan; ok {
+		t.Error("Expected channel to close at end of file")
+	}
+}
+
+func TestNewInputCapture_Errors(t *testing.T) {
+	if _, err := newInputCapture("/non/existent/audio.raw", "f32le", 16000); err == nil || !strings.Contains(err.Error(), "failed to open input") {
+		t.Errorf("Expected open error, got %v", err)
+	}
+	if _, err := newInputCapture("-", "u8", 16000); err == nil || !strings.Contains(err.Error(), "unsupported input format") {
+		t.Errorf("Expected format error, got %v", err)
+	}
+}
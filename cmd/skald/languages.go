@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// printLanguages lists the language codes accepted by -language for the
+// loaded model
+func printLanguages(w io.Writer, languages []string) {
+	switch {
+	case languages == nil:
+		fmt.Fprintln(w, "This model does not report its supported languages")
+	case len(languages) == 1 && languages[0] == "en":
+		fmt.Fprintln(w, "This model is English-only (not multilingual); the only supported language is: en")
+	default:
+		fmt.Fprintf(w, "Supported languages (%d): auto, %s\n", len(languages), strings.Join(languages, ", "))
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintLanguages(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []string
+		want      string
+	}{
+		{"multilingual", []string{"de", "en", "fr"}, "Supported languages (3): auto, de, en, fr"},
+		{"english only", []string{"en"}, "English-only (not multilingual)"},
+		{"unknown", nil, "does not report"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			printLanguages(&buf, tt.languages)
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("printLanguages() = %q, want it to contain %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
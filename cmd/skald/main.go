@@ -4,15 +4,22 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"math"
 	"os"
+	"os/exec"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
+	"skald/internal/logging"
 	"skald/internal/validation"
+	"skald/pkg/skald"
 	"skald/pkg/skald/app"
 	"skald/pkg/skald/audio"
+	"skald/pkg/skald/backend"
 	"skald/pkg/skald/output"
 	"skald/pkg/skald/transcriber"
 )
@@ -22,6 +29,7 @@ const (
 	defaultSilenceThreshold = 0.01
 	defaultSilenceDuration  = 1.5
 	defaultModelPath        = "models/ggml-large-v3-turbo.bin"
+	defaultStartRetryDelay  = 500 * time.Millisecond
 )
 
 // Version will be set at build time
@@ -41,8 +49,64 @@ func validateSampleRate(rate int) error {
 	return nil
 }
 
+// voiceCommandFlag collects repeated -voice-command "phrase=action" values
+type voiceCommandFlag map[string]string
+
+func (v voiceCommandFlag) String() string {
+	pairs := make([]string, 0, len(v))
+	for phrase, action := range v {
+		pairs = append(pairs, phrase+"="+action)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (v voiceCommandFlag) Set(value string) error {
+	phrase, action, ok := strings.Cut(value, "=")
+	phrase = strings.TrimSpace(phrase)
+	action = strings.TrimSpace(action)
+	if !ok || phrase == "" {
+		return fmt.Errorf("expected phrase=action, got %q", value)
+	}
+	if !app.IsValidVoiceAction(action) {
+		return fmt.Errorf("unknown voice command action %q (valid: stop, newline, paragraph)", action)
+	}
+	v[phrase] = action
+	return nil
+}
+
+// headerFlag collects repeated -backend-header "Name: value" values
+type headerFlag map[string]string
+
+func (h headerFlag) String() string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	return strings.Join(names, ",") // Values may hold credentials
+}
+
+func (h headerFlag) Set(value string) error {
+	name, headerValue, ok := strings.Cut(value, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return fmt.Errorf("expected Name: value, got %q", value)
+	}
+	h[name] = strings.TrimSpace(headerValue)
+	return nil
+}
 
 func main() {
+	// Subcommands take precedence over the default live transcription mode
+	if len(os.Args) > 1 && os.Args[1] == "batch" {
+		os.Exit(runBatch(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "eval" {
+		os.Exit(runEval(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "calibrate" {
+		os.Exit(runCalibrate(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var (
 		modelPath  = flag.String("model", defaultModelPath, "Path to whisper model")
 		language   = flag.String("language", "auto", "Language code (e.g., en, es, auto)")
@@ -51,8 +115,62 @@ func main() {
 		silenceThreshold = flag.Float64("silence-threshold", defaultSilenceThreshold, "Silence threshold (0-1)")
 		silenceDuration = flag.Float64("silence-duration", defaultSilenceDuration, "Silence duration in seconds")
 		noClipboard = flag.Bool("no-clipboard", false, "Disable clipboard output")
+		outputFormat = flag.String("output-format", "text", "Output format: text or json (newline-delimited, no clipboard)")
 		showVersion = flag.Bool("version", false, "Show version and exit")
+		showStats = flag.Bool("stats", false, "Print transcription latency statistics on exit")
+		logFormat = flag.String("log-format", logging.FormatText, "Log format: text or json")
+		deviceTimeout = flag.Duration("device-timeout", 0, "Stop if no audio arrives from the device for this long, e.g. 5s (0 disables)")
+		startRetries = flag.Int("start-retries", 0, "Extra attempts if the audio device fails to start, e.g. 3")
+		startRetryDelay = flag.Duration("start-retry-delay", defaultStartRetryDelay, "Delay between audio device start attempts")
+		idleTimeout = flag.Duration("idle-timeout", 0, "In continuous mode, stop after this long without speech (0 disables)")
+		maxSession = flag.Duration("max-session", 0, "In continuous mode, stop after this total duration (0 disables)")
+		statsFile = flag.String("stats-file", "", "JSON file for lifetime transcription totals across runs (optional)")
+		maxLatency = flag.Duration("max-latency", 0, "Drop the oldest audio when transcription falls this far behind (0 disables)")
+		captureBuffer = flag.Int("capture-buffer", audio.DefaultBufferSize, "Audio frames queued before new frames are dropped")
+		vad = flag.Bool("vad", false, "Skip transcribing buffers with no detected speech (saves CPU in noisy rooms)")
+		trimSilence = flag.Bool("trim-silence", false, "Cut leading and trailing silence from audio before transcribing")
+		warmup = flag.Bool("warmup", false, "Run a short silent transcription at startup so the first real one is not delayed")
+		normalizeSpacing = flag.Bool("normalize-spacing", false, "Trim whisper segments and join them with single spaces instead of their original spacing")
+		maxRepeat = flag.Int("max-repeat", 0, "Collapse phrases whisper repeats back to back more than this many times (0 disables)")
+		minConfidence = flag.Float64("min-confidence", 0, "Drop whisper segments with confidence below this (0-1, 0 disables)")
+		threads = flag.Int("threads", 0, "CPU threads per transcription (0 uses the whisper default)")
+		temperature = flag.Float64("temperature", 0, "Whisper sampling temperature (0-1, 0 is greedy and most deterministic)")
+		temperatureInc = flag.Float64("temperature-inc", transcriber.DefaultTemperatureIncrement, "Temperature added when whisper retries a failed decode (0 disables retries)")
+		clipboardMode = flag.String("clipboard-mode", "replace", "Clipboard mode: replace, or append to the current contents")
+		clipboardSeparator = flag.String("clipboard-separator", " ", "Text placed between phrases in append clipboard mode")
+		clipboardMaxLength = flag.Int("clipboard-max-length", output.DefaultMaxLength, "Longest clipboard contents, in bytes, built up in append mode")
+		partialInterval = flag.Duration("partial-interval", 0, "Show an interim result after this much new speech, e.g. 2s (0 disables)")
+		saveAudioDir = flag.String("save-audio-dir", "", "Save each transcribed buffer as a timestamped WAV file in this directory (for debugging)")
+		outputSeparator = flag.String("output-separator", "newline", "Printed after each transcription: newline, space or none")
+		statusFile = flag.String("status-file", "", "JSON file updated with the current state for status bars (optional)")
+		transcribeTimeout = flag.Duration("transcribe-timeout", 0, "Give up on a single transcription after this long, e.g. 30s (0 disables)")
+		execHook = flag.String("exec", "", "Command run with each transcription; {text} in an argument is replaced by it, otherwise it is sent on stdin")
+		execTimeout = flag.Duration("exec-timeout", app.DefaultHookTimeout, "Kill the -exec command after this long")
+		inputFile = flag.String("input-file", "", "Read raw mono samples at -sample-rate from this file instead of the microphone (- for stdin)")
+		inputFormat = flag.String("input-format", audio.FormatF32LE, "Sample format for -input-file: f32le or s16le")
+		streamSegments = flag.Bool("stream-segments", false, "Show each whisper segment as soon as it is decoded, replaced by the final text")
+		skipPunctuation = flag.Bool("skip-punctuation-only", false, "Discard results containing only punctuation and whitespace, such as \".\"")
+		quiet = flag.Bool("quiet", false, "Only log warnings and errors")
+		once = flag.Bool("once", false, "Transcribe one utterance, print it to stdout and exit (for scripts; implies -quiet and -no-clipboard)")
+		listLanguages = flag.Bool("list-languages", false, "List the language codes the model supports, then exit")
+		strictLanguage = flag.Bool("strict-language", false, "Exit with an error instead of a warning when -language auto is used with an English-only model")
+		captureFormat = flag.String("capture-format", audio.FormatF32LE, "Sample format the audio device records in: f32le or s16le")
+		preRoll = flag.Duration("pre-roll", 0, "Audio before a pause carried into the next buffer so quiet word onsets are not cut off, e.g. 300ms (0 disables)")
+		backendName = flag.String("backend", backend.Default, "Transcription backend: "+strings.Join(backend.Names(), ", "))
+		backendURL = flag.String("backend-url", "", "Transcription service URL for -backend http")
+		backendTimeout = flag.Duration("backend-timeout", transcriber.DefaultHTTPTimeout, "Request timeout for -backend http")
+		backendTextField = flag.String("backend-text-field", "text", "JSON field holding the transcript for -backend http; empty reads a plain text response")
+		capturePeriod = flag.Int("capture-period", 0, "Samples the audio device delivers per callback, a power of two from 64 to 16384 (0 uses the backend default)")
+		startTimeout = flag.Duration("start-timeout", 0, "Give up if the audio device takes longer than this to start, e.g. 10s (0 disables)")
+		wakeWord = flag.String("wake-word", "", "Ignore speech until this phrase is heard, e.g. \"hey skald\" (empty disables)")
+		sleepWord = flag.String("sleep-word", "", "Ignore speech again after this phrase, until -wake-word is heard")
+		dumpConfigFlag = flag.Bool("dump-config", false, "Print the effective configuration as JSON, with header values redacted, then exit")
+		check = flag.Bool("check", false, "Check configuration, model, audio device and clipboard, then exit")
 	)
+	voiceCommands := voiceCommandFlag{}
+	flag.Var(voiceCommands, "voice-command", "Spoken phrase mapped to an action, as phrase=action (repeatable; actions: stop, newline, paragraph)")
+	backendHeaders := headerFlag{}
+	flag.Var(backendHeaders, "backend-header", "Header sent to the -backend http service, as \"Name: value\" (repeatable)")
 	flag.Parse()
 
 	// Handle version flag
@@ -61,41 +179,201 @@ func main() {
 		return
 	}
 
+	// Report on the setup without starting a session
+	if *check {
+		configErr := validateOptions(*sampleRate, *startRetries, *captureBuffer, *captureFormat, *outputFormat, *clipboardMode, *outputSeparator)
+		if configErr == nil {
+			configErr = logging.ValidateFormat(*logFormat)
+		}
+		useClipboard := !*noClipboard && *outputFormat == "text"
+		// Note: the audio check is skipped unless configErr is nil, so the rate is in range
+		checkRate := uint32(*sampleRate) //nolint:gosec
+		os.Exit(runChecks(os.Stdout, setupChecks(configErr, *backendName, *modelPath, *backendURL, checkRate, *captureFormat, useClipboard)))
+	}
+
+	// Switch the standard logger to structured output if requested
+	var logOutput io.Writer = os.Stderr
+	if *logFormat == logging.FormatJSON {
+		logger, err := logging.New(os.Stderr, *logFormat, "skald")
+		if err != nil {
+			log.Fatalf("Invalid log format: %v", err)
+		}
+		log.SetFlags(0)
+		logOutput = logger
+	} else if err := logging.ValidateFormat(*logFormat); err != nil {
+		log.Fatalf("Invalid log format: %v", err)
+	}
+	if *quiet || *once {
+		logOutput = logging.NewQuietWriter(logOutput)
+	}
+	log.SetOutput(logOutput)
+
+	// Expand ~ and environment variables in output paths, as for the model path
+	*statsFile = validation.ExpandPath(*statsFile)
+	*saveAudioDir = validation.ExpandPath(*saveAudioDir)
+	*statusFile = validation.ExpandPath(*statusFile)
+	if *inputFile != "-" {
+		*inputFile = validation.ExpandPath(*inputFile)
+	}
+
+	// Show the settings in effect after defaults and path expansion
+	if *dumpConfigFlag {
+		if err := dumpConfig(os.Stdout, flag.CommandLine); err != nil {
+			log.Fatalf("Failed to print configuration: %v", err)
+		}
+		return
+	}
+
 	// Validate and secure model path
-	validatedModelPath, err := validation.ValidateModelPath(*modelPath)
-	if err != nil {
-		log.Fatalf("Invalid model path: %v", err)
+	validatedModelPath := *modelPath
+	if *backendName == backend.Default {
+		path, err := validation.ValidateModelPath(*modelPath)
+		if err != nil {
+			log.Fatalf("Invalid model path: %v", err)
+		}
+		validatedModelPath = path
+	}
+
+	// Validate sample rate and other options before use
+	if err := validateOptions(*sampleRate, *startRetries, *captureBuffer, *captureFormat, *outputFormat, *clipboardMode, *outputSeparator); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if *sleepWord != "" && *wakeWord == "" {
+		log.Fatalf("Invalid configuration: -sleep-word needs -wake-word")
 	}
 
-	// Validate sample rate before use
-	if err := validateSampleRate(*sampleRate); err != nil {
-		log.Fatalf("Invalid sample rate: %v", err)
+	// Split the hook command on spaces; quoting is not supported
+	commandHook := strings.Fields(*execHook)
+	if len(commandHook) > 0 {
+		if _, err := exec.LookPath(commandHook[0]); err != nil {
+			log.Fatalf("Invalid exec command: %v", err)
+		}
 	}
 
 	// Create components with validated sample rate
 	// Note: Safe conversion after validation - sampleRate already checked to be within uint32 range
 	safeRate := uint32(*sampleRate) //nolint:gosec
-	audioCapture := audio.NewCapture(safeRate)
+	var audioCapture skald.AudioCapture
+	if *inputFile != "" {
+		readerCapture, err := newInputCapture(*inputFile, *inputFormat, safeRate)
+		if err != nil {
+			log.Fatalf("Invalid input file: %v", err)
+		}
+		audioCapture = readerCapture
+	} else {
+		deviceCapture := audio.NewCaptureWithBuffer(safeRate, *captureBuffer)
+		// Note: the format was checked by validateOptions
+		_ = deviceCapture.SetSampleFormat(*captureFormat)
+		if err := deviceCapture.SetPeriodSize(*capturePeriod); err != nil {
+			log.Fatalf("Invalid capture period: %v", err)
+		}
+		audioCapture = deviceCapture
+	}
 	
-	whisperTranscriber, err := transcriber.NewWhisper(validatedModelPath, *language)
+	activeTranscriber, err := backend.New(*backendName, backend.Config{
+		Model:      validatedModelPath,
+		Language:   *language,
+		SampleRate: safeRate,
+		URL:        *backendURL,
+		Headers:    backendHeaders,
+		Timeout:    *backendTimeout,
+		TextField:  *backendTextField,
+	})
 	if err != nil {
 		log.Fatalf("Failed to create transcriber: %v", err)
 	}
-	defer whisperTranscriber.Close()
+	defer activeTranscriber.Close()
+
+	// Model tuning only applies to the local whisper backend
+	whisperTranscriber, isWhisper := activeTranscriber.(*transcriber.Whisper)
+	if *listLanguages {
+		var languages []string
+		if isWhisper {
+			languages = whisperTranscriber.SupportedLanguages()
+		}
+		printLanguages(os.Stdout, languages)
+		return
+	}
+	if isWhisper {
+		if err := whisperTranscriber.CheckAutoDetect(); err != nil {
+			if *strictLanguage {
+				log.Fatalf("Invalid language: %v", err)
+			}
+			log.Printf("Warning: %v; transcribing everything as English", err)
+		}
+		whisperTranscriber.SetNormalizeSpacing(*normalizeSpacing)
+		whisperTranscriber.SetMaxRepeat(*maxRepeat)
+		whisperTranscriber.SetMinConfidence(float32(*minConfidence))
+		if err := whisperTranscriber.SetThreads(*threads); err != nil {
+			log.Fatalf("Invalid threads: %v", err)
+		}
+		if err := whisperTranscriber.SetTemperature(float32(*temperature), float32(*temperatureInc)); err != nil {
+			log.Fatalf("Invalid temperature: %v", err)
+		}
+
+		if *warmup {
+			elapsed, err := whisperTranscriber.Warmup()
+			if err != nil {
+				log.Printf("Warning: %v", err)
+			} else {
+				log.Printf("Model warm-up took %v", elapsed.Round(time.Millisecond))
+			}
+		}
+	}
 
-	clipboardOutput := output.NewClipboardOutput(os.Stdout, !*noClipboard)
+	// Warn once about a missing clipboard tool instead of on every transcription
+	useClipboard := !*noClipboard && !*once && *outputFormat == "text"
+	if useClipboard {
+		if err := output.CheckClipboard(); err != nil {
+			log.Printf("Warning: %v; clipboard copying is disabled for this session", err)
+			useClipboard = false
+		}
+	}
+	clipboardOutput := output.NewClipboardOutput(os.Stdout, useClipboard)
+	// Note: already checked by validateOptions
+	lineEnd, _ := output.ParseLineEnd(*outputSeparator)
+	clipboardOutput.SetLineEnd(lineEnd)
+	if *clipboardMode == "append" {
+		clipboardOutput.SetAppendMode(*clipboardSeparator)
+		clipboardOutput.SetMaxLength(*clipboardMaxLength)
+	}
+	var textOutput skald.Output = clipboardOutput
+	if *outputFormat == "json" {
+		textOutput = output.NewJSONOutput(os.Stdout)
+	}
 	silenceDetector := audio.NewSilenceDetector()
 
 	// Create app configuration
 	config := app.Config{
-		SampleRate:       safeRate,
-		SilenceThreshold: float32(*silenceThreshold),
-		SilenceDuration:  float32(*silenceDuration),
-		Continuous:       *continuous,
+		SampleRate:          safeRate,
+		SilenceThreshold:    float32(*silenceThreshold),
+		SilenceDuration:     float32(*silenceDuration),
+		Continuous:          *continuous,
+		VoiceCommands:       voiceCommands,
+		DeviceTimeout:       *deviceTimeout,
+		StartRetries:        *startRetries,
+		StartRetryDelay:     *startRetryDelay,
+		StartTimeout:        *startTimeout,
+		IdleTimeout:         *idleTimeout,
+		MaxSessionDuration:  *maxSession,
+		MaxLatency:          *maxLatency,
+		VADEnabled:          *vad,
+		TrimSilence:         *trimSilence,
+		PartialInterval:     *partialInterval,
+		SaveAudioDir:        *saveAudioDir,
+		TranscribeTimeout:   *transcribeTimeout,
+		CommandHook:         commandHook,
+		CommandTimeout:      *execTimeout,
+		StreamSegments:      *streamSegments,
+		SkipPunctuationOnly: *skipPunctuation,
+		PreRoll:             *preRoll,
+		WakeWord:            *wakeWord,
+		SleepWord:           *sleepWord,
+		Once:                *once,
 	}
 
 	// Create and run app
-	application := app.New(audioCapture, whisperTranscriber, clipboardOutput, silenceDetector, config)
+	application := app.New(audioCapture, activeTranscriber, textOutput, silenceDetector, config)
 
 	// Setup signal handling
 	ctx, cancel := context.WithCancel(context.Background())
@@ -110,8 +388,102 @@ func main() {
 		cancel()
 	}()
 
+	// Load lifetime totals so usage stats survive restarts
+	var lifetime lifetimeStats
+	if *statsFile != "" {
+		lifetime, err = loadLifetimeStats(*statsFile)
+		if err != nil {
+			log.Fatalf("Invalid stats file: %v", err)
+		}
+	}
+	startTime := time.Now()
+	currentTotals := func() lifetimeStats {
+		return lifetime.add(application.Stats(), time.Since(startTime))
+	}
+
+	if *statsFile != "" {
+		go func() {
+			ticker := time.NewTicker(statsSaveInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := saveLifetimeStats(*statsFile, currentTotals()); err != nil {
+						log.Printf("Warning: %v", err)
+					}
+				}
+			}
+		}()
+	}
+
+	// Keep the status file in step with the app for status bars
+	currentStatus := func() statusReport {
+		return newStatusReport(application.Status(), application.Stats())
+	}
+	statusCtx, stopStatus := context.WithCancel(ctx)
+	statusDone := make(chan struct{})
+	if *statusFile != "" {
+		go func() {
+			defer close(statusDone)
+			watchStatus(statusCtx, *statusFile, statusPollInterval, currentStatus)
+		}()
+	} else {
+		close(statusDone)
+	}
+
 	// Run the app
-	if err := application.Run(ctx); err != nil && err != context.Canceled {
-		log.Fatalf("Error: %v", err)
+	runErr := application.Run(ctx)
+
+	stopStatus()
+	<-statusDone
+	if *statusFile != "" {
+		report := currentStatus()
+		report.State = stateStopped
+		if err := writeStatusFile(*statusFile, report); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	if *statsFile != "" {
+		if err := saveLifetimeStats(*statsFile, currentTotals()); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	if counter, ok := audioCapture.(skald.FrameDropCounter); ok {
+		if dropped := counter.DroppedFrames(); dropped > 0 {
+			log.Printf("Warning: %d audio frames were dropped because processing fell behind; consider a larger -capture-buffer", dropped)
+		}
+	}
+
+	if *showStats {
+		printStats(application.Stats())
+		if *statsFile != "" {
+			totals := currentTotals()
+			log.Printf("Lifetime: %d transcriptions, %d errors, %s total runtime",
+				totals.Transcriptions, totals.Errors,
+				(time.Duration(totals.RuntimeSeconds) * time.Second).String())
+		}
+	}
+
+	if runErr != nil && runErr != context.Canceled {
+		log.Fatalf("Error: %v", runErr)
+	}
+}
+
+// printStats logs a latency summary so users can judge whether their hardware keeps up
+func printStats(stats app.Stats) {
+	if stats.Transcriptions == 0 {
+		log.Printf("No transcriptions performed (%d failed)", stats.Errors)
+		return
 	}
+	log.Printf("Transcriptions: %d (%d failed), avg latency: %dms (min %dms, max %dms), realtime factor: %.2f",
+		stats.Transcriptions,
+		stats.Errors,
+		stats.AvgLatency().Milliseconds(),
+		stats.MinLatency.Milliseconds(),
+		stats.MaxLatency.Milliseconds(),
+		stats.RealtimeFactor())
 }
\ No newline at end of file
@@ -73,6 +73,9 @@ func TestMain_HelpFlag(t *testing.T) {
 	expectedFlags := []string{
 		"model", "language", "continuous", "sample-rate",
 		"silence-threshold", "silence-duration", "no-clipboard", "version",
+		"voice-command", "stats", "log-format", "device-timeout",
+		"start-retries", "start-retry-delay", "stats-file", "idle-timeout",
+		"max-session", "output-format", "check", "max-latency", "capture-buffer", "vad", "trim-silence", "warmup", "normalize-spacing", "max-repeat", "min-confidence", "threads", "clipboard-mode", "clipboard-separator", "clipboard-max-length", "partial-interval", "save-audio-dir", "output-separator", "status-file", "transcribe-timeout", "exec", "exec-timeout", "input-file", "input-format", "stream-segments", "skip-punctuation-only", "quiet", "list-languages", "strict-language", "capture-format", "pre-roll", "backend", "backend-url", "backend-timeout", "backend-text-field", "backend-header", "capture-period", "start-timeout", "temperature", "temperature-inc", "dump-config", "wake-word", "sleep-word", "once",
 	}
 	
 	for _, flagName := range expectedFlags {
@@ -396,4 +399,56 @@ func TestMain_FlagParsing(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+// TestVoiceCommandFlag_Set tests parsing of -voice-command values
+func TestVoiceCommandFlag_Set(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr bool
+	}{
+		{name: "valid stop command", value: "stop dictation=stop"},
+		{name: "valid newline command", value: " new line = newline "},
+		{name: "missing separator", value: "stop dictation", expectErr: true},
+		{name: "empty phrase", value: "=stop", expectErr: true},
+		{name: "unknown action", value: "go away=explode", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			commands := voiceCommandFlag{}
+			err := commands.Set(tc.value)
+			if (err != nil) != tc.expectErr {
+				t.Errorf("Set(%q) error = %v, expectErr %v", tc.value, err, tc.expectErr)
+			}
+		})
+	}
+
+	commands := voiceCommandFlag{}
+	if err := commands.Set(" new line = newline "); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+	if commands["new line"] != "newline" {
+		t.Errorf("Expected trimmed phrase and action, got %v", map[string]string(commands))
+	}
+}
+
+// TestHeaderFlag_Set tests parsing of -backend-header values
+func TestHeaderFlag_Set(t *testing.T) {
+	headers := headerFlag{}
+	if err := headers.Set("Authorization: Bearer a:b"); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+	if headers["Authorization"] != "Bearer a:b" {
+		t.Errorf("Expected value split at the first colon, got %v", map[string]string(headers))
+	}
+	if strings.Contains(headers.String(), "Bearer") {
+		t.Errorf("String() should not reveal header values, got %q", headers.String())
+	}
+
+	for _, value := range []string{"Authorization", ": value"} {
+		if err := headers.Set(value); err == nil {
+			t.Errorf("Set(%q) expected error", value)
+		}
+	}
+}
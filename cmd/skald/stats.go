@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"skald/pkg/skald/app"
+)
+
+// statsSaveInterval controls how often lifetime stats are flushed while running
+const statsSaveInterval = 30 * time.Second
+
+// lifetimeStats holds cumulative counters persisted across runs
+type lifetimeStats struct {
+	Transcriptions int     `json:"total_transcriptions"`
+	Errors         int     `json:"total_errors"`
+	RuntimeSeconds float64 `json:"total_runtime_seconds"`
+}
+
+// add returns the totals with a session's stats and runtime included
+func (l lifetimeStats) add(stats app.Stats, runtime time.Duration) lifetimeStats {
+	l.Transcriptions += stats.Transcriptions
+	l.Errors += stats.Errors
+	l.RuntimeSeconds += runtime.Seconds()
+	return l
+}
+
+// statsFileMu serializes writes from the periodic saver and the final save
+var statsFileMu sync.Mutex
+
+// loadLifetimeStats reads stats from path; a missing file yields zero totals
+func loadLifetimeStats(path string) (lifetimeStats, error) {
+	var stats lifetimeStats
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, fmt.Errorf("failed to read stats file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return stats, fmt.Errorf("failed to parse stats file: %w", err)
+	}
+	return stats, nil
+}
+
+// saveLifetimeStats atomically writes stats to path via a temp file and rename
+func saveLifetimeStats(path string, stats lifetimeStats) error {
+	statsFileMu.Lock()
+	defer statsFileMu.Unlock()
+
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode stats: %w", err)
+	}
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to save stats file: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic replaces path with data via a temp file in the same
+// directory and a rename, so readers never see a partially written file
+func writeFileAtomic(path string, data []byte) error {
+	path = filepath.Clean(path)
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+	return nil
+}
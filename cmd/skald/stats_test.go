@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"skald/pkg/skald/app"
+)
+
+func TestLifetimeStats_Add(t *testing.T) {
+	base := lifetimeStats{Transcriptions: 10, Errors: 1, RuntimeSeconds: 60}
+	totals := base.add(app.Stats{Transcriptions: 3, Errors: 2}, 30*time.Second)
+
+	if totals.Transcriptions != 13 || totals.Errors != 3 || totals.RuntimeSeconds != 90 {
+		t.Errorf("Unexpected totals: %+v", totals)
+	}
+	if base.Transcriptions != 10 {
+		t.Error("add() should not modify the receiver")
+	}
+}
+
+func TestLifetimeStats_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+
+	// Missing file yields zero totals
+	stats, err := loadLifetimeStats(path)
+	if err != nil {
+		t.Fatalf("loadLifetimeStats() on missing file error = %v", err)
+	}
+	if stats != (lifetimeStats{}) {
+		t.Errorf("Expected zero stats, got %+v", stats)
+	}
+
+	want := lifetimeStats{Transcriptions: 42, Errors: 3, RuntimeSeconds: 1234.5}
+	if err := saveLifetimeStats(path, want); err != nil {
+		t.Fatalf("saveLifetimeStats() error = %v", err)
+	}
+
+	got, err := loadLifetimeStats(path)
+	if err != nil {
+		t.Fatalf("loadLifetimeStats() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Loaded %+v, want %+v", got, want)
+	}
+
+	// No temp files should be left behind
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected only the stats file in directory, found %d entries", len(entries))
+	}
+}
+
+func TestLifetimeStats_LoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadLifetimeStats(path); err == nil {
+		t.Error("Expected error for corrupt stats file")
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"skald/pkg/skald/app"
+)
+
+// statusPollInterval controls how quickly the status file follows state changes
+const statusPollInterval = 250 * time.Millisecond
+
+// Values of statusReport.State
+const (
+	stateListening    = "listening"
+	stateTranscribing = "transcribing"
+	stateStopped      = "stopped"
+)
+
+// statusReport is the JSON written to -status-file for status bars
+type statusReport struct {
+	State             string     `json:"state"`
+	LastTranscription *time.Time `json:"last_transcription,omitempty"`
+	Transcriptions    int        `json:"transcriptions"`
+	Errors            int        `json:"errors"`
+}
+
+// newStatusReport summarises the app's status and stats
+func newStatusReport(status app.Status, stats app.Stats) statusReport {
+	report := statusReport{
+		State:          stateListening,
+		Transcriptions: stats.Transcriptions,
+		Errors:         stats.Errors,
+	}
+	if status.Transcribing {
+		report.State = stateTranscribing
+	}
+	if !status.LastTranscription.IsZero() {
+		last := status.LastTranscription
+		report.LastTranscription = &last
+	}
+	return report
+}
+
+// writeStatusFile atomically replaces path with report
+func writeStatusFile(path string, report statusReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode status: %w", err)
+	}
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to save status file: %w", err)
+	}
+	return nil
+}
+
+// watchStatus writes the report to path whenever it changes, checking every
+// interval until ctx is cancelled
+func watchStatus(ctx context.Context, path string, interval time.Duration, current func() statusReport) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last statusReport
+	for {
+		report := current()
+		if !sameStatus(report, last) {
+			if err := writeStatusFile(path, report); err != nil {
+				log.Printf("Warning: %v", err)
+			} else {
+				last = report
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sameStatus reports whether two reports would write the same file
+func sameStatus(a, b statusReport) bool {
+	if a.State != b.State || a.Transcriptions != b.Transcriptions || a.Errors != b.Errors {
+		return false
+	}
+	if a.LastTranscription == nil || b.LastTranscription == nil {
+		return a.LastTranscription == b.LastTranscription
+	}
+	return a.LastTranscription.Equal(*b.LastTranscription)
+}
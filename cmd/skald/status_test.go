@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"skald/pkg/skald/app"
+)
+
+func TestNewStatusReport(t *testing.T) {
+	last := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name      string
+		status    app.Status
+		wantState string
+		wantLast  bool
+	}{
+		{"idle", app.Status{}, stateListening, false},
+		{"transcribing", app.Status{Transcribing: true}, stateTranscribing, false},
+		{"after a transcription", app.Status{LastTranscription: last}, stateListening, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newStatusReport(tt.status, app.Stats{Transcriptions: 4, Errors: 1})
+			if report.State != tt.wantState {
+				t.Errorf("State = %q, want %q", report.State, tt.wantState)
+			}
+			if (report.LastTranscription != nil) != tt.wantLast {
+				t.Errorf("LastTranscription = %v, want set %v", report.LastTranscription, tt.wantLast)
+			}
+			if report.Transcriptions != 4 || report.Errors != 1 {
+				t.Errorf("Unexpected counts: %+v", report)
+			}
+		})
+	}
+}
+
+func TestWriteStatusFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+	last := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := writeStatusFile(path, statusReport{State: stateTranscribing, LastTranscription: &last, Transcriptions: 2}); err != nil {
+		t.Fatalf("writeStatusFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"state":"transcribing","last_transcription":"2025-01-02T03:04:05Z","transcriptions":2,"errors":0}` + "\n"
+	if string(data) != want {
+		t.Errorf("Status file = %q, want %q", data, want)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected temp file to be cleaned up, found %d entries", len(entries))
+	}
+}
+
+func TestWatchStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+	var transcribing atomic.Bool
+	current := func() statusReport {
+		return newStatusReport(app.Status{Transcribing: transcribing.Load()}, app.Stats{})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watchStatus(ctx, path, time.Millisecond, current)
+	}()
+
+	waitForState := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			var report statusReport
+			if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &report) == nil && report.State == want {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		t.Fatalf("Status file never reached state %q", want)
+	}
+
+	waitForState(stateListening)
+	transcribing.Store(true)
+	waitForState(stateTranscribing)
+
+	cancel()
+	<-done
+}
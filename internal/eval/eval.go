@@ -0,0 +1,134 @@
+// Package eval measures transcription accuracy against reference transcripts
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"unicode"
+
+	"skald/pkg/skald"
+	"skald/pkg/skald/batch"
+)
+
+// Score counts word-level edits against a reference transcript
+type Score struct {
+	Edits int // Substitutions, insertions and deletions
+	Words int // Words in the reference
+}
+
+// WER returns the word error rate, the share of reference words that had to
+// be edited. It can exceed 1 when the hypothesis has many extra words.
+func (s Score) WER() float64 {
+	if s.Words == 0 {
+		if s.Edits == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(s.Edits) / float64(s.Words)
+}
+
+// Add combines two scores, weighting each file by its reference length
+func (s Score) Add(other Score) Score {
+	return Score{Edits: s.Edits + other.Edits, Words: s.Words + other.Words}
+}
+
+// Compare scores a hypothesis transcript against a reference
+func Compare(reference, hypothesis string) Score {
+	ref := Tokenize(reference)
+	return Score{Edits: Distance(ref, Tokenize(hypothesis)), Words: len(ref)}
+}
+
+// Tokenize splits text into lowercase words without surrounding punctuation,
+// so "Hello, World!" and "hello world" compare equal
+func Tokenize(text string) []string {
+	var words []string
+	for _, field := range strings.Fields(text) {
+		word := strings.ToLower(strings.TrimFunc(field, unicode.IsPunct))
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Distance returns the Levenshtein distance between two word sequences
+func Distance(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// FileResult is the score for one audio file
+type FileResult struct {
+	Path  string
+	Score Score
+	Err   error
+}
+
+// ErrNoReference is reported for audio files without a reference transcript
+var ErrNoReference = errors.New("no reference transcript")
+
+// Run transcribes every supported audio file under dir and scores it
+// against the reference transcript next to it (the same name with a .txt
+// extension, as written by batch mode). The overall score only includes
+// files that were scored successfully.
+func Run(dir string, transcriber skald.Transcriber) ([]FileResult, Score, error) {
+	files, err := batch.FindFiles(dir)
+	if err != nil {
+		return nil, Score{}, err
+	}
+
+	results := make([]FileResult, 0, len(files))
+	var total Score
+	for _, path := range files {
+		result := scoreFile(path, transcriber)
+		if result.Err == nil {
+			total = total.Add(result.Score)
+		}
+		results = append(results, result)
+	}
+	return results, total, nil
+}
+
+// scoreFile transcribes one file and compares it with its reference
+func scoreFile(path string, transcriber skald.Transcriber) FileResult {
+	result := FileResult{Path: path}
+
+	reference, err := os.ReadFile(batch.OutputPath(path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			result.Err = ErrNoReference
+		} else {
+			result.Err = fmt.Errorf("failed to read reference: %w", err)
+		}
+		return result
+	}
+
+	text, err := batch.TranscribeFile(path, transcriber)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Score = Compare(string(reference), text)
+	return result
+}
@@ -0,0 +1,116 @@
+package eval
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skald/pkg/skald/mocks"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want int
+	}{
+		{"identical", []string{"the", "cat", "sat"}, []string{"the", "cat", "sat"}, 0},
+		{"substitution", []string{"the", "cat", "sat"}, []string{"the", "bat", "sat"}, 1},
+		{"deletion", []string{"the", "cat", "sat"}, []string{"the", "sat"}, 1},
+		{"insertion", []string{"the", "cat"}, []string{"the", "fat", "cat"}, 1},
+		{"all different", []string{"a", "b"}, []string{"c", "d", "e"}, 3},
+		{"empty reference", nil, []string{"a", "b"}, 2},
+		{"empty hypothesis", []string{"a", "b"}, nil, 2},
+		{"kitten sitting", []string{"k", "i", "t", "t", "e", "n"}, []string{"s", "i", "t", "t", "i", "n", "g"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("Distance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	score := Compare("Hello, world! How are you?", "hello world how you")
+	if score.Edits != 1 || score.Words != 5 {
+		t.Errorf("Compare() = %+v, want 1 edit over 5 words", score)
+	}
+	if wer := score.WER(); wer != 0.2 {
+		t.Errorf("WER() = %v, want 0.2", wer)
+	}
+}
+
+func TestScore_WER(t *testing.T) {
+	tests := []struct {
+		score Score
+		want  float64
+	}{
+		{Score{Edits: 0, Words: 0}, 0},
+		{Score{Edits: 2, Words: 0}, 1},
+		{Score{Edits: 3, Words: 2}, 1.5},
+		{Score{Edits: 1, Words: 4}.Add(Score{Edits: 1, Words: 6}), 0.2},
+	}
+
+	for _, tt := range tests {
+		if got := tt.score.WER(); got != tt.want {
+			t.Errorf("%+v.WER() = %v, want %v", tt.score, got, tt.want)
+		}
+	}
+}
+
+// writeRaw writes float32 little-endian samples to path
+func writeRaw(t *testing.T, path string, samples []float32) {
+	t.Helper()
+	data := make([]byte, len(samples)*4)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(s))
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	writeRaw(t, filepath.Join(dir, "a.raw"), []float32{0.1})
+	writeRaw(t, filepath.Join(dir, "b.raw"), []float32{0.1})
+	writeRaw(t, filepath.Join(dir, "c.raw"), []float32{0.1})
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("turn the lights on\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.txt"), []byte("Turn the lights off."), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	transcriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			return "Turn the lights on.", nil
+		},
+	}
+
+	results, total, err := Run(dir, transcriber)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	if results[0].Err != nil || results[0].Score != (Score{Edits: 0, Words: 4}) {
+		t.Errorf("Unexpected result for a.raw: %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Score != (Score{Edits: 1, Words: 4}) {
+		t.Errorf("Unexpected result for b.raw: %+v", results[1])
+	}
+	if !errors.Is(results[2].Err, ErrNoReference) {
+		t.Errorf("Expected ErrNoReference for c.raw, got %v", results[2].Err)
+	}
+	if total != (Score{Edits: 1, Words: 8}) {
+		t.Errorf("Total = %+v, want 1 edit over 8 words", total)
+	}
+}
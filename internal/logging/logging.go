@@ -0,0 +1,177 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supported log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Log levels
+const (
+	LevelInfo  = "INFO"
+	LevelWarn  = "WARN"
+	LevelError = "ERROR"
+)
+
+// Entry is a single structured log line
+type Entry struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Level     string         `json:"level"`
+	Component string         `json:"component"`
+	Message   string         `json:"message"`
+	Fields    map[string]any `json:"fields,omitempty"`
+}
+
+// Logger writes log entries as plain text or JSON lines.
+// It also implements io.Writer so it can back the standard library logger
+// via log.SetOutput, letting existing log.Printf call sites adopt it unchanged.
+type Logger struct {
+	mu        sync.Mutex
+	out       io.Writer
+	format    string
+	component string
+	now       func() time.Time
+}
+
+// ValidateFormat checks that format is a supported log format
+func ValidateFormat(format string) error {
+	switch format {
+	case FormatText, FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("unsupported log format %q (valid: %s, %s)", format, FormatText, FormatJSON)
+}
+
+// New creates a logger writing to out in the given format
+func New(out io.Writer, format, component string) (*Logger, error) {
+	if err := ValidateFormat(format); err != nil {
+		return nil, err
+	}
+	return &Logger{
+		out:       out,
+		format:    format,
+		component: component,
+		now:       time.Now,
+	}, nil
+}
+
+// Log writes a single entry with optional structured fields
+func (l *Logger) Log(level, message string, fields map[string]any) error {
+	entry := Entry{
+		Timestamp: l.now().UTC(),
+		Level:     level,
+		Component: l.component,
+		Message:   message,
+		Fields:    fields,
+	}
+
+	var line []byte
+	if l.format == FormatJSON {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode log entry: %w", err)
+		}
+		line = append(data, '\n')
+	} else {
+		line = []byte(formatText(entry))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err := l.out.Write(line)
+	return err
+}
+
+// Printf logs an informational message, matching log.Printf
+func (l *Logger) Printf(format string, args ...any) {
+	_ = l.Log(LevelInfo, fmt.Sprintf(format, args...), nil)
+}
+
+// Warnf logs a warning message
+func (l *Logger) Warnf(format string, args ...any) {
+	_ = l.Log(LevelWarn, fmt.Sprintf(format, args...), nil)
+}
+
+// Errorf logs an error message
+func (l *Logger) Errorf(format string, args ...any) {
+	_ = l.Log(LevelError, fmt.Sprintf(format, args...), nil)
+}
+
+// Write implements io.Writer for use with log.SetOutput.
+// The level is inferred from the message: warnings and errors are recognized
+// by their wording, everything else is logged as INFO.
+func (l *Logger) Write(p []byte) (int, error) {
+	message := strings.TrimSpace(string(p))
+	if err := l.Log(inferLevel(message), message, nil); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// stdTimestamp matches the date and time the standard logger puts before each message
+var stdTimestamp = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}(\.\d+)? `)
+
+// QuietWriter drops informational lines written by the standard logger and
+// passes warnings and errors on to the underlying writer
+type QuietWriter struct {
+	out io.Writer
+}
+
+// NewQuietWriter creates a writer for log.SetOutput that only lets warnings
+// and errors through to out
+func NewQuietWriter(out io.Writer) *QuietWriter {
+	return &QuietWriter{out: out}
+}
+
+// Write implements io.Writer. Levels are inferred as in Logger.Write.
+func (q *QuietWriter) Write(p []byte) (int, error) {
+	message := strings.TrimSpace(stdTimestamp.ReplaceAllString(string(p), ""))
+	if inferLevel(message) == LevelInfo {
+		return len(p), nil
+	}
+	return q.out.Write(p)
+}
+
+// inferLevel guesses a level for messages from plain log.Printf call sites
+// from how they start, such as "Warning:", "Failed to" or a label like
+// "Transcription error:". Words later in the message are ignored, so a
+// summary mentioning "0 errors" stays informational.
+func inferLevel(message string) string {
+	lower := strings.ToLower(message)
+	label, _, _ := strings.Cut(lower, ":")
+	switch {
+	case strings.HasPrefix(lower, "warning"):
+		return LevelWarn
+	case strings.HasPrefix(lower, "failed"), strings.HasPrefix(lower, "invalid"), strings.HasSuffix(label, "error"):
+		return LevelError
+	}
+	return LevelInfo
+}
+
+// formatText renders an entry as a single human-readable line
+func formatText(entry Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s [%s] %s: %s",
+		entry.Timestamp.Format(time.RFC3339), entry.Level, entry.Component, entry.Message)
+	keys := make([]string, 0, len(entry.Fields))
+	for key := range entry.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&b, " %s=%v", key, entry.Fields[key])
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fixedTime() time.Time {
+	return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+}
+
+func TestNew_InvalidFormat(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "xml", "skald"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestLogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, FormatJSON, "app")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	logger.now = fixedTime
+
+	if err := logger.Log(LevelWarn, `quote " and newline`+"\n", map[string]any{"samples": 16000}); err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Output is not valid JSON: %v (%q)", err, buf.String())
+	}
+	if entry.Level != LevelWarn || entry.Component != "app" {
+		t.Errorf("Unexpected level/component: %+v", entry)
+	}
+	if entry.Message != `quote " and newline`+"\n" {
+		t.Errorf("Message not preserved: %q", entry.Message)
+	}
+	if !entry.Timestamp.Equal(fixedTime()) {
+		t.Errorf("Timestamp = %v, want %v", entry.Timestamp, fixedTime())
+	}
+	if entry.Fields["samples"] != float64(16000) {
+		t.Errorf("Fields not preserved: %v", entry.Fields)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("Expected exactly one line, got %q", buf.String())
+	}
+}
+
+func TestLogger_Text(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&buf, FormatText, "skald")
+	logger.now = fixedTime
+
+	_ = logger.Log(LevelInfo, "Listening", map[string]any{"b": 2, "a": 1})
+
+	expected := "2025-01-02T03:04:05Z [INFO] skald: Listening a=1 b=2\n"
+	if buf.String() != expected {
+		t.Errorf("Text output = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestLogger_StandardLoggerAdapter(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&buf, FormatJSON, "skald")
+
+	std := log.New(logger, "", 0)
+	std.Printf("Transcription error: %v", "boom")
+	std.Println("Warning: clipboard unavailable")
+	std.Println("Listening... Press Ctrl+C to stop")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+
+	expectedLevels := []string{LevelError, LevelWarn, LevelInfo}
+	for i, line := range lines {
+		var entry Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i, err)
+		}
+		if entry.Level != expectedLevels[i] {
+			t.Errorf("Line %d level = %s, want %s", i, entry.Level, expectedLevels[i])
+		}
+		if strings.HasSuffix(entry.Message, "\n") {
+			t.Errorf("Line %d message should be trimmed: %q", i, entry.Message)
+		}
+	}
+}
+
+func TestQuietWriter(t *testing.T) {
+	var buf bytes.Buffer
+	std := log.New(NewQuietWriter(&buf), "", log.LstdFlags)
+	std.Println("Listening... Press Ctrl+C to stop")
+	std.Println("Warning: clipboard unavailable")
+	std.Printf("Transcription error: %v", "boom")
+	std.Printf("Invalid model path: %v", "missing")
+	std.Println("\nStopping...")
+	std.Printf("Lifetime: %d transcriptions, %d errors", 3, 0)
+	std.Println("Saved audio to error-log.wav")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+	for i, want := range []string{"Warning: clipboard unavailable", "Transcription error: boom", "Invalid model path: missing"} {
+		if !strings.HasSuffix(lines[i], want) {
+			t.Errorf("Line %d = %q, want suffix %q", i, lines[i], want)
+		}
+	}
+}
@@ -10,10 +10,25 @@ import (
 
 const ggmlMagic = 0x67676d6c // "ggml" in hex
 
+// ExpandPath expands environment variables such as $HOME or
+// $XDG_RUNTIME_DIR and a leading ~ in path. A ~ is left alone if the home
+// directory cannot be determined.
+func ExpandPath(path string) string {
+	path = os.ExpandEnv(path)
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
 // ValidateModelPath validates and secures the model file path
 func ValidateModelPath(path string) (string, error) {
-	// Clean the path to prevent path traversal
-	cleanPath := filepath.Clean(path)
+	// Expand ~ and variables, then clean the path to prevent path traversal
+	cleanPath := filepath.Clean(ExpandPath(path))
 	
 	// Check if file exists
 	if _, err := os.Stat(cleanPath); err != nil {
@@ -3,6 +3,7 @@ package validation
 import (
 	"encoding/binary"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -82,6 +83,46 @@ func TestValidateModelPath(t *testing.T) {
 	}
 }
 
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/tester")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"~", "/home/tester"},
+		{"~/models/base.bin", "/home/tester/models/base.bin"},
+		{"$HOME/models/base.bin", "/home/tester/models/base.bin"},
+		{"${XDG_RUNTIME_DIR}/skald.sock", "/run/user/1000/skald.sock"},
+		{"models/~base.bin", "models/~base.bin"},
+		{"~other/base.bin", "~other/base.bin"},
+		{"/abs/path.bin", "/abs/path.bin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := ExpandPath(tt.path); got != tt.want {
+				t.Errorf("ExpandPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateModelPath_Expansion(t *testing.T) {
+	path, cleanup := createValidGGMLFile(t)
+	defer cleanup()
+	t.Setenv("SKALD_TEST_MODEL_DIR", filepath.Dir(path))
+
+	result, err := ValidateModelPath("$SKALD_TEST_MODEL_DIR/" + filepath.Base(path))
+	if err != nil {
+		t.Fatalf("ValidateModelPath() error = %v", err)
+	}
+	if filepath.Base(result) != filepath.Base(path) {
+		t.Errorf("ValidateModelPath() = %q, want file %q", result, filepath.Base(path))
+	}
+}
+
 func TestValidateGGMLHeader(t *testing.T) {
 	tests := []struct {
 		name          string
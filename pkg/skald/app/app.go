@@ -2,27 +2,82 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
 
 	"skald/pkg/skald"
 )
 
+// ErrAudioTimeout is returned when the audio device stops delivering frames
+var ErrAudioTimeout = errors.New("no audio received from capture device")
+
+// ErrStartTimeout is returned when the audio device takes longer than
+// StartTimeout to start
+var ErrStartTimeout = errors.New("audio device did not start")
+
+// ErrTranscribeTimeout is returned when a transcription exceeds TranscribeTimeout
+var ErrTranscribeTimeout = errors.New("transcription timed out")
+
 // Config holds application configuration
 type Config struct {
-	SampleRate       uint32
-	SilenceThreshold float32
-	SilenceDuration  float32
-	Continuous       bool
+	SampleRate          uint32
+	SilenceThreshold    float32
+	SilenceDuration     float32
+	Continuous          bool
+	VoiceCommands       map[string]string // Spoken phrase -> action (stop, newline, paragraph)
+	DeviceTimeout       time.Duration     // Max gap between audio frames before giving up (0 disables)
+	StartRetries        int               // Extra attempts if the audio device fails to start
+	StartRetryDelay     time.Duration     // Wait between audio start attempts
+	StartTimeout        time.Duration     // Give up if the audio device takes longer than this to start (0 disables)
+	IdleTimeout         time.Duration     // End continuous mode after this long without speech (0 disables)
+	MaxSessionDuration  time.Duration     // End continuous mode after this total duration (0 disables)
+	MaxLatency          time.Duration     // Drop the oldest queued audio beyond this backlog (0 disables)
+	VADEnabled          bool              // Skip transcribing buffers without detected speech
+	TrimSilence         bool              // Cut quiet audio from both ends of a buffer before transcribing
+	PartialInterval     time.Duration     // Show an interim result after this much new speech (0 disables)
+	SaveAudioDir        string            // Save each transcribed buffer as a WAV file here (empty disables)
+	TranscribeTimeout   time.Duration     // Give up waiting for a single transcription after this long (0 disables)
+	CommandHook         []string          // Program and arguments run with each transcription, see runCommandHook
+	CommandTimeout      time.Duration     // Kill the command hook after this long (0 uses DefaultHookTimeout)
+	StreamSegments      bool              // Show each segment as an interim result as soon as it is decoded
+	SkipPunctuationOnly bool              // Treat results with no letters or digits, such as ".", as empty
+	PreRoll             time.Duration     // Audio before a silence cut carried into the next buffer (0 disables)
+	WakeWord            string            // Ignore speech until this phrase is heard (empty disables)
+	SleepWord           string            // Ignore speech again after this phrase, until the wake phrase
+	Once                bool              // Stop after the first transcription is written, even in continuous mode
 }
 
 // App represents the main application
 type App struct {
-	audio           skald.AudioCapture
-	transcriber     skald.Transcriber
-	output          skald.Output
-	silenceDetector skald.SilenceDetector
-	config          Config
+	audio             skald.AudioCapture
+	transcriber       skald.Transcriber
+	output            skald.Output
+	silenceDetector   skald.SilenceDetector
+	config            Config
+	stopRequested     bool // Set when a spoken stop command, idle timeout, end of audio or Once ends the run
+	idleSamples       int  // Consecutive silent samples across sessions
+	stats             statsRecorder
+	reportedDrops     uint64         // Dropped capture frames already logged
+	transcribing      atomic.Bool    // Set while the transcriber is running
+	bufferedSamples   atomic.Int64   // Samples in the current session buffer
+	lastTranscription atomic.Int64   // Unix nanoseconds of the last successful transcription
+	workers           sync.WaitGroup // Background goroutines started by Run, including command hooks
+	lastHook          chan struct{}  // Closed when the most recent command hook finishes
+	transcriptions    sync.WaitGroup // Transcriber calls run with a timeout, including abandoned ones
+	abandoned         atomic.Int32   // Timed-out transcriptions still running
+	generation        atomic.Uint64  // Bumped when a transcription is abandoned, to drop its streamed segments
+	onTranscription   func(text string, meta skald.Meta)
+	stopMu            sync.Mutex
+	stop              context.CancelFunc // Ends the current Run, nil when not running
+	wake              *wakeGate          // Nil unless WakeWord is set
+	voiceCommands     []voiceCommand     // Compiled from VoiceCommands by New
+	startMu           sync.Mutex         // Orders a late Stop from an abandoned start with newer starts
+	startAttempt      atomic.Uint64      // Counts audio start attempts
 }
 
 // New creates a new application instance
@@ -33,19 +88,92 @@ func New(audio skald.AudioCapture, transcriber skald.Transcriber, output skald.O
 		output:          output,
 		silenceDetector: silenceDetector,
 		config:          config,
+		wake:            newWakeGate(config.WakeWord, config.SleepWord),
+		voiceCommands:   compileVoiceCommands(config.VoiceCommands),
 	}
 }
 
-// Run starts the transcription process
+// Stats returns transcription latency statistics collected so far
+func (app *App) Stats() Stats {
+	return app.stats.snapshot()
+}
+
+// OnTranscription sets a function called with each transcription after it
+// has been written to the output, for programs embedding the app that want
+// to observe results without replacing the output. Set it before Run.
+func (app *App) OnTranscription(fn func(text string, meta skald.Meta)) {
+	app.onTranscription = fn
+}
+
+// Stop ends the current Run, which transcribes any buffered audio and
+// returns nil. It does nothing if the app is not running, and Run may be
+// called again afterwards.
+func (app *App) Stop() {
+	app.stopMu.Lock()
+	defer app.stopMu.Unlock()
+	if app.stop != nil {
+		app.stop()
+	}
+}
+
+// setStop records the function Stop calls
+func (app *App) setStop(stop context.CancelFunc) {
+	app.stopMu.Lock()
+	app.stop = stop
+	app.stopMu.Unlock()
+}
+
+// Run starts the transcription process. It returns when ctx is cancelled,
+// Stop is called, the audio stream ends, or a spoken stop command, Once,
+// IdleTimeout or MaxSessionDuration ends the session.
 func (app *App) Run(ctx context.Context) error {
-	audioChan, err := app.audio.Start(ctx)
+	parent := ctx
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	app.setStop(stop)
+	defer app.setStop(nil)
+
+	// Start afresh if a previous Run ended on a stop command or idle timeout
+	app.stopRequested = false
+	app.idleSamples = 0
+	if app.wake != nil {
+		app.wake.awake = false
+	}
+
+	audioChan, err := app.startAudio(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to start audio capture: %w", err)
+		if errors.Is(err, context.Canceled) && parent.Err() == nil {
+			return nil // Stopped during startup
+		}
+		return err
 	}
 	defer app.audio.Stop()
 
+	// Stop and wait for background goroutines before returning, so they
+	// cannot race with a later Run or with the capture being stopped
+	workerCtx, stopWorkers := context.WithCancel(ctx)
+	defer app.workers.Wait()
+	defer stopWorkers()
+	defer app.waitAbandoned()
+
+	if app.config.MaxLatency > 0 {
+		if app.isLive() {
+			audioChan = app.limitBacklog(workerCtx, audioChan)
+		} else {
+			log.Println("Ignoring max latency: input is not captured live, so no audio is dropped")
+		}
+	}
+
 	log.Println("Listening... Press Ctrl+C to stop")
 
+	// Cap continuous sessions; processSession flushes the buffer when the deadline fires
+	sessionCtx := ctx
+	if app.config.Continuous && app.config.MaxSessionDuration > 0 {
+		var cancel context.CancelFunc
+		sessionCtx, cancel = context.WithTimeout(ctx, app.config.MaxSessionDuration)
+		defer cancel()
+	}
+
 	for {
 		// Create session with 25-second max to stay safely under Whisper's 30s limit
 		maxDurationSeconds := float32(25.0)
@@ -56,67 +184,203 @@ func (app *App) Run(ctx context.Context) error {
 			maxSamples:      int(float32(app.config.SampleRate) * maxDurationSeconds),
 		}
 
-		if err := app.processSession(ctx, audioChan, session); err != nil {
+		if err := app.processSession(sessionCtx, audioChan, session); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				log.Printf("Maximum session duration of %v reached, stopping", app.config.MaxSessionDuration)
+				return nil
+			}
+			if errors.Is(err, context.Canceled) && parent.Err() == nil {
+				return nil // Stopped by Stop
+			}
 			return err
 		}
 
-		if !app.config.Continuous {
+		if !app.config.Continuous || app.stopRequested {
 			return nil
 		}
 	}
 }
 
+// startAudio starts audio capture, retrying transient failures such as a
+// device still held by another application
+func (app *App) startAudio(ctx context.Context) (<-chan []float32, error) {
+	attempts := app.config.StartRetries + 1
+	for attempt := 1; ; attempt++ {
+		audioChan, err := app.startWithTimeout(ctx)
+		if err == nil {
+			return audioChan, nil
+		}
+		if errors.Is(err, ErrStartTimeout) {
+			// A wedged device would only hang again, and the stuck attempt may still be running
+			return nil, err
+		}
+		if attempt >= attempts {
+			if attempts > 1 {
+				return nil, fmt.Errorf("failed to start audio capture after %d attempts: %w", attempts, err)
+			}
+			return nil, fmt.Errorf("failed to start audio capture: %w", err)
+		}
+
+		log.Printf("Warning: audio start attempt %d/%d failed: %v, retrying in %v", attempt, attempts, err, app.config.StartRetryDelay)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(app.config.StartRetryDelay):
+		}
+	}
+}
+
+// startWithTimeout starts audio capture, giving up after StartTimeout so a
+// wedged audio subsystem cannot hang Run. A start that completes after the
+// caller gave up is stopped again, unless a newer attempt has started the
+// capture since, which the late Stop would otherwise tear down.
+func (app *App) startWithTimeout(ctx context.Context) (<-chan []float32, error) {
+	app.startMu.Lock()
+	attempt := app.startAttempt.Add(1)
+	app.startMu.Unlock()
+
+	if app.config.StartTimeout <= 0 {
+		return app.audio.Start(ctx)
+	}
+
+	type startResult struct {
+		audioChan <-chan []float32
+		err       error
+	}
+	done := make(chan startResult, 1)
+	go func() {
+		audioChan, err := app.audio.Start(ctx)
+		done <- startResult{audioChan, err}
+	}()
+	stopLate := func() {
+		go func() {
+			if result := <-done; result.err == nil {
+				app.startMu.Lock()
+				defer app.startMu.Unlock()
+				if app.startAttempt.Load() == attempt {
+					app.audio.Stop()
+				}
+			}
+		}()
+	}
+
+	timer := time.NewTimer(app.config.StartTimeout)
+	defer timer.Stop()
+	select {
+	case result := <-done:
+		return result.audioChan, result.err
+	case <-timer.C:
+		stopLate()
+		return nil, fmt.Errorf("%w within %v", ErrStartTimeout, app.config.StartTimeout)
+	case <-ctx.Done():
+		stopLate()
+		return nil, ctx.Err()
+	}
+}
+
 // TranscriptionSession holds state for a single transcription session
 type TranscriptionSession struct {
 	buffer          []float32
 	silentSamples   int
 	silentThreshold int
 	maxSamples      int // Maximum samples before forced transcription (30s limit)
+	partialSamples  int // Buffer length when the last interim result was shown
 }
 
 // processSession processes a single transcription session with automatic chunking
 func (app *App) processSession(ctx context.Context, audioChan <-chan []float32, session *TranscriptionSession) error {
+	defer app.bufferedSamples.Store(0)
+
+	// Watchdog fires if the device stops delivering frames (e.g. mic unplugged)
+	var timer *time.Timer
+	var watchdog <-chan time.Time
+	if app.config.DeviceTimeout > 0 {
+		timer = time.NewTimer(app.config.DeviceTimeout)
+		defer timer.Stop()
+		watchdog = timer.C
+	}
+	// restartWatchdog is called after work that blocks the loop, such as a
+	// transcription: frames keep queueing meanwhile, so the time it took must
+	// not count as a stalled device. Reset discards an expiry that fired
+	// during the work.
+	restartWatchdog := func() {
+		if timer != nil {
+			timer.Reset(app.config.DeviceTimeout)
+		}
+	}
+
 	for {
 		select {
+		case <-watchdog:
+			// Device stalled; flush what we have and end the session cleanly
+			if len(session.buffer) > 0 {
+				if err := app.transcribeAndOutput(context.WithoutCancel(ctx), session.buffer); err != nil {
+					log.Printf("Final transcription error: %v", err)
+				}
+			}
+			return fmt.Errorf("%w for %v, the device may have been disconnected", ErrAudioTimeout, app.config.DeviceTimeout)
 		case <-ctx.Done():
 			// Process any remaining audio before exiting
 			if len(session.buffer) > 0 {
-				if err := app.transcribeAndOutput(session.buffer); err != nil {
+				if err := app.transcribeAndOutput(context.WithoutCancel(ctx), session.buffer); err != nil {
 					log.Printf("Final transcription error: %v", err)
 				}
 			}
 			return ctx.Err()
 		case samples, ok := <-audioChan:
 			if !ok {
-				// Channel closed, process any remaining audio
+				// Channel closed, process any remaining audio; no more will
+				// arrive, so continuous mode ends too
 				if len(session.buffer) > 0 {
-					if err := app.transcribeAndOutput(session.buffer); err != nil {
+					if err := app.transcribeAndOutput(context.WithoutCancel(ctx), session.buffer); err != nil {
 						log.Printf("Final transcription error: %v", err)
 					}
 				}
+				app.stopRequested = true
 				return nil
 			}
 
+			if timer != nil {
+				timer.Reset(app.config.DeviceTimeout)
+			}
+
 			// Append to buffer
 			session.buffer = append(session.buffer, samples...)
+			app.bufferedSamples.Store(int64(len(session.buffer)))
 
 			// Check for silence
 			isSilent := app.silenceDetector.IsSilent(samples, app.config.SilenceThreshold)
 
 			if isSilent {
 				session.silentSamples += len(samples)
+				app.idleSamples += len(samples)
 			} else {
 				session.silentSamples = 0
+				app.idleSamples = 0
+			}
+
+			// Stop continuous mode once nobody has spoken for the idle timeout
+			if app.isIdle() {
+				log.Printf("No speech for %v, stopping", app.config.IdleTimeout)
+				if len(session.buffer) > 0 {
+					if err := app.transcribeAndOutput(context.WithoutCancel(ctx), session.buffer); err != nil {
+						log.Printf("Final transcription error: %v", err)
+					}
+				}
+				app.stopRequested = true
+				return nil
 			}
 
 			// Determine if we should process the buffer
 			shouldProcess := false
 			resetBuffer := false
+			silenceCut := false
 
 			// Condition 1: Silence detected (original behavior)
 			if session.silentSamples >= session.silentThreshold && len(session.buffer) > 0 {
 				shouldProcess = true
 				resetBuffer = true
+				silenceCut = true
 			}
 
 			// Condition 2: Buffer reached max duration (25 seconds)
@@ -127,37 +391,272 @@ func (app *App) processSession(ctx context.Context, audioChan <-chan []float32,
 			}
 
 			if shouldProcess {
-				if err := app.transcribeAndOutput(session.buffer); err != nil {
+				// A stop or the session deadline can abort this call; the
+				// buffer is then kept so the ctx.Done flush transcribes it
+				if err := app.transcribeAndOutput(ctx, session.buffer); err != nil {
+					if ctx.Err() != nil {
+						continue
+					}
 					log.Printf("Transcription error: %v", err)
 				}
+				restartWatchdog()
+				app.reportDroppedFrames()
 				
 				if resetBuffer {
-					// Reset buffer and silence counter
-					session.buffer = make([]float32, 0)
+					// Reset buffer and silence counter, keeping the pre-roll
+					// after a silence cut. A cut at the length limit keeps
+					// nothing, since its tail is speech that was just transcribed.
+					keep := 0
+					if silenceCut {
+						keep = app.preRollSamples()
+					}
+					session.buffer = carryPreRoll(session.buffer, keep)
 					session.silentSamples = 0
+					session.partialSamples = 0
+					app.bufferedSamples.Store(int64(len(session.buffer)))
+				}
+
+				// Exit if a spoken stop command was recognized
+				if app.stopRequested {
+					return nil
 				}
 
 				// Exit if not in continuous mode and silence was detected
 				if !app.config.Continuous && session.silentSamples >= session.silentThreshold {
 					return nil
 				}
+			} else if app.partialDue(session) {
+				app.emitPartial(ctx, session.buffer)
+				restartWatchdog()
+				session.partialSamples = len(session.buffer)
 			}
 		}
 	}
 }
 
-// transcribeAndOutput transcribes audio and outputs the result
-func (app *App) transcribeAndOutput(buffer []float32) error {
-	text, err := app.transcriber.Transcribe(buffer)
+// preRollSamples returns how many samples PreRoll covers
+func (app *App) preRollSamples() int {
+	return int(app.config.PreRoll.Seconds() * float64(app.config.SampleRate))
+}
+
+// carryPreRoll starts a new buffer with the last n samples of buffer. The
+// start of a word is often quiet enough to count as silence, so it can end
+// up just before a silence cut; carrying it over keeps it from being lost.
+func carryPreRoll(buffer []float32, n int) []float32 {
+	n = min(n, len(buffer))
+	if n <= 0 {
+		return make([]float32, 0)
+	}
+	return append(make([]float32, 0, n), buffer[len(buffer)-n:]...)
+}
+
+// isIdle reports whether continuous mode has seen no speech for IdleTimeout
+func (app *App) isIdle() bool {
+	if !app.config.Continuous || app.config.IdleTimeout <= 0 {
+		return false
+	}
+	idleLimit := int(app.config.IdleTimeout.Seconds() * float64(app.config.SampleRate))
+	return app.idleSamples >= idleLimit
+}
+
+// transcribeAndOutput transcribes audio and outputs the result. If ctx is
+// cancelled and the transcriber aborts, it returns ctx.Err() and writes
+// nothing, leaving the caller to flush the buffer; a result that completes
+// anyway is still written so the utterance is not lost.
+func (app *App) transcribeAndOutput(ctx context.Context, buffer []float32) error {
+	if app.config.TrimSilence {
+		trimmed := app.trimSilence(buffer)
+		if len(trimmed) == 0 {
+			// Quiet speech that never crosses the threshold ends up here too
+			log.Printf("Skipped %.1fs of audio below the silence threshold", audioDuration(len(buffer), app.config.SampleRate).Seconds())
+			return nil
+		}
+		buffer = trimmed
+	}
+	if !app.hasSpeech(buffer) {
+		return nil
+	}
+	warnClipping(buffer)
+	app.saveAudio(buffer)
+
+	start := time.Now()
+	app.transcribing.Store(true)
+	result, err := app.transcribe(ctx, buffer, app.segmentStreamer())
+	app.transcribing.Store(false)
+	latency := time.Since(start)
+	if ctx.Err() != nil {
+		if err != nil {
+			return ctx.Err()
+		}
+		ctx = context.WithoutCancel(ctx)
+	}
+	app.stats.record(latency, audioDuration(len(buffer), app.config.SampleRate), err != nil)
 	if err != nil {
 		return fmt.Errorf("transcription failed: %w", err)
 	}
+	app.lastTranscription.Store(time.Now().UnixNano())
+
+	if app.config.SkipPunctuationOnly && isPunctuationOnly(result.Text) {
+		result.Text = ""
+	}
+	if result.Text == "" {
+		logEmptyResult(result)
+		return nil
+	}
+	if app.wake != nil {
+		result.Text = app.wake.filter(result.Text)
+		if result.Text == "" {
+			return nil // Asleep, or only the wake or sleep phrase was spoken
+		}
+	}
+
+	text, stop := applyVoiceCommands(result.Text, app.voiceCommands)
+	if stop {
+		app.stopRequested = true
+	}
 
 	if text != "" {
-		if err := app.output.Write(text); err != nil {
+		meta := skald.Meta{Language: result.Language, Latency: latency, Confidence: result.Confidence}
+		if err := app.write(text, meta); err != nil {
 			return fmt.Errorf("output failed: %w", err)
 		}
+		if app.onTranscription != nil {
+			app.onTranscription(text, meta)
+		}
+		app.runHook(ctx, text)
+		if app.config.Once {
+			app.stopRequested = true
+		}
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// isPunctuationOnly reports whether text holds nothing but punctuation and
+// whitespace, like the stray "." whisper sometimes returns for silence
+func isPunctuationOnly(text string) bool {
+	for _, r := range text {
+		if !unicode.IsPunct(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// logEmptyResult explains why a transcription produced no text: either
+// whisper recognized nothing, or it only heard sounds it annotated as
+// non-speech, which means the microphone is working but picked up no words
+func logEmptyResult(result skald.Result) {
+	if result.NonSpeech > 0 {
+		log.Printf("Heard only non-speech audio such as [BLANK_AUDIO] (%d segment(s)), nothing to output", result.NonSpeech)
+		return
+	}
+	log.Println("No speech recognized")
+}
+
+// hasSpeech reports whether buffer is worth transcribing. Without VAD, or if
+// the silence detector cannot detect speech, every buffer is transcribed.
+func (app *App) hasSpeech(buffer []float32) bool {
+	if !app.config.VADEnabled {
+		return true
+	}
+	detector, ok := app.silenceDetector.(skald.SpeechDetector)
+	if !ok {
+		return true
+	}
+	return detector.HasSpeech(buffer, app.config.SampleRate, app.config.SilenceThreshold)
+}
+
+// trimSilence cuts leading and trailing silence if the silence detector supports it
+func (app *App) trimSilence(buffer []float32) []float32 {
+	trimmer, ok := app.silenceDetector.(skald.SilenceTrimmer)
+	if !ok {
+		return buffer
+	}
+	return trimmer.TrimSilence(buffer, app.config.SampleRate, app.config.SilenceThreshold)
+}
+
+// transcribe runs the transcriber, giving up after TranscribeTimeout. A
+// transcription that times out is abandoned rather than stopped: whisper
+// can only abort before encoding starts, so the call keeps running in the
+// background until it finishes and its result is discarded. Whisper cannot
+// run two transcriptions on one model, so the next call waits for it to
+// finish first, as does Run before returning so the model is not closed
+// under it.
+func (app *App) transcribe(ctx context.Context, buffer []float32, onSegment func(string)) (skald.Result, error) {
+	app.waitAbandoned()
+	timeout := app.config.TranscribeTimeout
+	if timeout <= 0 {
+		return app.runTranscriber(ctx, buffer, onSegment)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		result skald.Result
+		err    error
+	}
+	done := make(chan outcome, 1)
+	app.transcriptions.Add(1)
+	go func() {
+		defer app.transcriptions.Done()
+		result, err := app.runTranscriber(timeoutCtx, buffer, onSegment)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-timeoutCtx.Done():
+		if ctx.Err() != nil {
+			// Stopping, not a timeout: wait as without a timeout so the
+			// model is not closed while whisper is still using it
+			out := <-done
+			return out.result, out.err
+		}
+		// Segments the abandoned call still decodes belong to no result
+		app.generation.Add(1)
+		app.abandoned.Add(1)
+		go func() {
+			<-done
+			app.abandoned.Add(-1)
+		}()
+		return skald.Result{}, fmt.Errorf("%w after %v", ErrTranscribeTimeout, timeout)
+	}
+}
+
+// waitAbandoned waits for timed-out transcriptions that are still running,
+// since the transcriber may not be used again or closed while they use it
+func (app *App) waitAbandoned() {
+	if n := app.abandoned.Load(); n > 0 {
+		log.Printf("Waiting for %d timed-out transcription(s) to finish", n)
+	}
+	app.transcriptions.Wait()
+}
+
+// runTranscriber runs the transcriber, collecting details such as the
+// language when it can report them and cancelling early when it supports
+// that. onSegment, if not nil, receives segments as they are decoded by
+// transcribers that can stream them.
+func (app *App) runTranscriber(ctx context.Context, buffer []float32, onSegment func(string)) (skald.Result, error) {
+	if streaming, ok := app.transcriber.(skald.StreamingTranscriber); ok && onSegment != nil {
+		return streaming.TranscribeStream(ctx, buffer, onSegment)
+	}
+	if cancelable, ok := app.transcriber.(skald.CancelableTranscriber); ok {
+		return cancelable.TranscribeContext(ctx, buffer)
+	}
+	if detailed, ok := app.transcriber.(skald.DetailedTranscriber); ok {
+		return detailed.TranscribeDetailed(buffer)
+	}
+	text, err := app.transcriber.Transcribe(buffer)
+	return skald.Result{Text: text}, err
+}
+
+// write sends text to the output, including metadata if the output accepts it
+func (app *App) write(text string, meta skald.Meta) error {
+	if metaOutput, ok := app.output.(skald.MetaOutput); ok {
+		return metaOutput.WriteMeta(text, meta)
+	}
+	return app.output.Write(text)
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -338,6 +339,59 @@ func TestProcessSession_ContextCancellation(t *testing.T) {
 	}
 }
 
+// TestProcessSession_StopDuringTranscription tests that a result finishing
+// after stop is still written once instead of losing the utterance
+func TestProcessSession_StopDuringTranscription(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockTranscriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			cancel() // Stop arrives while whisper is still running
+			return "late result", nil
+		},
+	}
+	mockOutput := &mocks.MockOutput{}
+	mockSilence := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool {
+			return false
+		},
+	}
+
+	config := Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  1.5,
+	}
+
+	app := &App{
+		transcriber:     mockTranscriber,
+		output:          mockOutput,
+		silenceDetector: mockSilence,
+		config:          config,
+	}
+
+	session := &TranscriptionSession{
+		buffer:          make([]float32, 0),
+		silentThreshold: int(float32(config.SampleRate) * config.SilenceDuration),
+		maxSamples:      16000,
+	}
+
+	// A full chunk triggers transcription on the normal path
+	audioChan := make(chan []float32, 1)
+	audioChan <- make([]float32, 16000)
+
+	if err := app.processSession(ctx, audioChan, session); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if mockOutput.WriteCalled != 1 || mockOutput.LastText != "late result" {
+		t.Errorf("Expected late result written once, got %d writes, last %q", mockOutput.WriteCalled, mockOutput.LastText)
+	}
+	if mockTranscriber.TranscribeCalled != 1 {
+		t.Errorf("Completed result should not be transcribed again, got %d calls", mockTranscriber.TranscribeCalled)
+	}
+}
+
 // TestProcessSession_ChunkingWithSilence tests interaction between chunking and silence detection
 func TestProcessSession_ChunkingWithSilence(t *testing.T) {
 	callCount := 0
@@ -534,4 +588,205 @@ func TestProcessSession_VeryLongContinuousAudio(t *testing.T) {
 			t.Errorf("Transcription interval too long: %v", interval)
 		}
 	}
-}
\ No newline at end of file
+}
+// TestProcessSession_DeviceTimeout tests that a stalled audio device ends the session
+func TestProcessSession_DeviceTimeout(t *testing.T) {
+	mockTranscriber := &mocks.MockTranscriber{}
+	mockSilence := &mocks.MockSilenceDetector{}
+
+	config := Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  1.5,
+		DeviceTimeout:    50 * time.Millisecond,
+	}
+
+	app := &App{
+		transcriber:     mockTranscriber,
+		output:          &mocks.MockOutput{},
+		silenceDetector: mockSilence,
+		config:          config,
+	}
+
+	session := &TranscriptionSession{
+		buffer:          make([]float32, 0),
+		silentThreshold: int(float32(config.SampleRate) * config.SilenceDuration),
+		maxSamples:      int(float32(config.SampleRate) * 25.0),
+	}
+
+	// Deliver a few frames, then stall without closing the channel
+	audioChan := make(chan []float32, 10)
+	for i := 0; i < 3; i++ {
+		audioChan <- make([]float32, 160)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.processSession(context.Background(), audioChan, session)
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrAudioTimeout) {
+			t.Errorf("Expected ErrAudioTimeout, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("processSession did not time out on stalled device")
+	}
+
+	// Buffered audio should be flushed before giving up
+	if mockTranscriber.TranscribeCalled != 1 {
+		t.Errorf("Expected remaining audio to be transcribed once, got %d calls", mockTranscriber.TranscribeCalled)
+	}
+}
+
+// TestProcessSession_DeviceTimeoutIgnoresSlowTranscription checks that time
+// spent transcribing, while frames keep arriving, is not taken for a stall
+func TestProcessSession_DeviceTimeoutIgnoresSlowTranscription(t *testing.T) {
+	mockTranscriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			time.Sleep(150 * time.Millisecond)
+			return "slow", nil
+		},
+	}
+	mockSilence := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool {
+			return samples[0] == 0
+		},
+	}
+	config := Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  0.001,
+		DeviceTimeout:    50 * time.Millisecond,
+	}
+	app := &App{
+		transcriber:     mockTranscriber,
+		output:          &mocks.MockOutput{},
+		silenceDetector: mockSilence,
+		config:          config,
+	}
+	session := &TranscriptionSession{
+		buffer:          make([]float32, 0),
+		silentThreshold: int(float32(config.SampleRate) * config.SilenceDuration),
+		maxSamples:      int(float32(config.SampleRate) * 25.0),
+	}
+
+	// A frame every 5ms, with a pause ending each of three utterances
+	audioChan := make(chan []float32, 100)
+	go func() {
+		defer close(audioChan)
+		for i := 0; i < 60; i++ {
+			frame := make([]float32, 16)
+			if i%20 != 19 {
+				frame[0] = 0.5
+			}
+			audioChan <- frame
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	if err := app.processSession(context.Background(), audioChan, session); err != nil {
+		t.Errorf("processSession() = %v, want nil while audio keeps arriving", err)
+	}
+	if mockTranscriber.TranscribeCalled == 0 {
+		t.Error("Expected the speech to be transcribed")
+	}
+}
+
+func TestCarryPreRoll(t *testing.T) {
+	tests := []struct {
+		name   string
+		buffer []float32
+		n      int
+		want   []float32
+	}{
+		{"disabled", []float32{1, 2, 3}, 0, []float32{}},
+		{"tail", []float32{1, 2, 3, 4}, 2, []float32{3, 4}},
+		{"longer than buffer", []float32{1, 2}, 5, []float32{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := carryPreRoll(tt.buffer, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("carryPreRoll() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("carryPreRoll() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestProcessSession_PreRoll tests that the end of a buffer cut on silence
+// starts the next buffer
+func TestProcessSession_PreRoll(t *testing.T) {
+	tests := []struct {
+		name       string
+		preRoll    time.Duration
+		wantSecond []float32
+	}{
+		{"disabled", 0, []float32{0.5, 0.5}},
+		{"carries tail of silence cut", 20 * time.Millisecond, []float32{0.003, 0.004, 0.5, 0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffers [][]float32
+			mockTranscriber := &mocks.MockTranscriber{
+				TranscribeFunc: func(audio []float32) (string, error) {
+					buffers = append(buffers, append([]float32(nil), audio...))
+					return "", nil
+				},
+			}
+			mockSilence := &mocks.MockSilenceDetector{
+				IsSilentFunc: func(samples []float32, threshold float32) bool {
+					return samples[0] < threshold
+				},
+			}
+			// 100Hz keeps the sample counts small: 40ms of silence ends a buffer
+			config := Config{
+				SampleRate:       100,
+				SilenceThreshold: 0.01,
+				SilenceDuration:  0.04,
+				Continuous:       true,
+				PreRoll:          tt.preRoll,
+			}
+			app := &App{
+				transcriber:     mockTranscriber,
+				output:          &mocks.MockOutput{},
+				silenceDetector: mockSilence,
+				config:          config,
+			}
+			session := &TranscriptionSession{
+				buffer:          make([]float32, 0),
+				silentThreshold: 4,
+				maxSamples:      2500,
+			}
+
+			audioChan := make(chan []float32, 3)
+			audioChan <- []float32{0.5, 0.5}
+			audioChan <- []float32{0.001, 0.002, 0.003, 0.004}
+			audioChan <- []float32{0.5, 0.5}
+			close(audioChan)
+
+			if err := app.processSession(context.Background(), audioChan, session); err != nil {
+				t.Fatalf("processSession() error = %v", err)
+			}
+			if len(buffers) != 2 {
+				t.Fatalf("Expected 2 transcriptions, got %d", len(buffers))
+			}
+			if len(buffers[1]) != len(tt.wantSecond) {
+				t.Fatalf("Second buffer = %v, want %v", buffers[1], tt.wantSecond)
+			}
+			for i := range buffers[1] {
+				if buffers[1][i] != tt.wantSecond[i] {
+					t.Errorf("Second buffer = %v, want %v", buffers[1], tt.wantSecond)
+				}
+			}
+		})
+	}
+}
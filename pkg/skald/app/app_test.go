@@ -1,11 +1,17 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"skald/pkg/skald"
 	"skald/pkg/skald/mocks"
 )
 
@@ -299,7 +305,7 @@ func TestApp_transcribeAndOutput(t *testing.T) {
 				output:      mockOutput,
 			}
 
-			err := app.transcribeAndOutput(tt.buffer)
+			err := app.transcribeAndOutput(context.Background(), tt.buffer)
 
 			if (err != nil) != tt.expectError {
 				t.Errorf("transcribeAndOutput() error = %v, expectError %v", err, tt.expectError)
@@ -389,4 +395,980 @@ func TestApp_processSession_TranscriptionErrorLogging(t *testing.T) {
 	if mockOutput.WriteCalled != 0 {
 		t.Errorf("Expected Write to not be called due to transcription error, got %d calls", mockOutput.WriteCalled)
 	}
-}
\ No newline at end of file
+}
+func TestApp_startAudio_Retries(t *testing.T) {
+	tests := []struct {
+		name          string
+		retries       int
+		failures      int
+		expectError   bool
+		expectedCalls int
+	}{
+		{name: "succeeds first time", retries: 2, failures: 0, expectedCalls: 1},
+		{name: "recovers after transient failure", retries: 2, failures: 2, expectedCalls: 3},
+		{name: "gives up after limit", retries: 1, failures: 5, expectError: true, expectedCalls: 2},
+		{name: "no retries configured", retries: 0, failures: 1, expectError: true, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			mockAudio := &mocks.MockAudioCapture{
+				StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+					calls++
+					if calls <= tt.failures {
+						return nil, errors.New("device busy")
+					}
+					return make(chan []float32), nil
+				},
+			}
+
+			app := &App{
+				audio: mockAudio,
+				config: Config{
+					StartRetries:    tt.retries,
+					StartRetryDelay: time.Millisecond,
+				},
+			}
+
+			_, err := app.startAudio(context.Background())
+			if (err != nil) != tt.expectError {
+				t.Errorf("startAudio() error = %v, expectError %v", err, tt.expectError)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("Expected %d start attempts, got %d", tt.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestApp_startAudio_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	stopped := make(chan struct{})
+	var attempts atomic.Int32
+	mockAudio := &mocks.MockAudioCapture{
+		StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+			attempts.Add(1)
+			<-release // Wedged until the test lets it finish
+			return make(chan []float32), nil
+		},
+		StopFunc: func() error {
+			close(stopped)
+			return nil
+		},
+	}
+
+	app := &App{
+		audio: mockAudio,
+		config: Config{
+			StartRetries:    3,
+			StartRetryDelay: time.Millisecond,
+			StartTimeout:    20 * time.Millisecond,
+		},
+	}
+
+	if _, err := app.startAudio(context.Background()); !errors.Is(err, ErrStartTimeout) {
+		t.Errorf("Expected ErrStartTimeout, got %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("Expected no retries after a timeout, got %d attempts", got)
+	}
+
+	// The abandoned start is stopped once it completes
+	close(release)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("Expected late start to be stopped")
+	}
+}
+
+func TestApp_startAudio_LateStartSparesNewerStart(t *testing.T) {
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	var attempts, stops atomic.Int32
+	mockAudio := &mocks.MockAudioCapture{
+		StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+			if attempts.Add(1) == 1 {
+				<-release // The first start is wedged until the test lets it finish
+				defer close(finished)
+			}
+			return make(chan []float32), nil
+		},
+		StopFunc: func() error {
+			stops.Add(1)
+			return nil
+		},
+	}
+
+	app := &App{
+		audio:  mockAudio,
+		config: Config{StartTimeout: 20 * time.Millisecond},
+	}
+
+	if _, err := app.startAudio(context.Background()); !errors.Is(err, ErrStartTimeout) {
+		t.Fatalf("Expected ErrStartTimeout, got %v", err)
+	}
+	if _, err := app.startAudio(context.Background()); err != nil {
+		t.Fatalf("Second start error = %v", err)
+	}
+
+	// The wedged start finishing must not stop the capture the second one started
+	close(release)
+	<-finished
+	time.Sleep(20 * time.Millisecond)
+	if got := stops.Load(); got != 0 {
+		t.Errorf("Late start stopped the newer capture (%d stops)", got)
+	}
+}
+
+func TestApp_startAudio_CancelDuringRetry(t *testing.T) {
+	mockAudio := &mocks.MockAudioCapture{
+		StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+			return nil, errors.New("device busy")
+		},
+	}
+
+	app := &App{
+		audio: mockAudio,
+		config: Config{
+			StartRetries:    10,
+			StartRetryDelay: time.Hour,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := app.startAudio(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context deadline error, got %v", err)
+	}
+	if mockAudio.StartCalled != 1 {
+		t.Errorf("Expected a single attempt before cancellation, got %d", mockAudio.StartCalled)
+	}
+}
+
+func TestApp_Run_AudioEndStopsContinuousMode(t *testing.T) {
+	audioChan := make(chan []float32, 2)
+	audioChan <- []float32{0.5, 0.5}
+	audioChan <- []float32{0.5, 0.5}
+	close(audioChan)
+
+	mockAudio := &mocks.MockAudioCapture{
+		StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+			return audioChan, nil
+		},
+	}
+	mockOutput := &mocks.MockOutput{}
+	app := New(mockAudio, &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			return "piped audio", nil
+		},
+	}, mockOutput, &mocks.MockSilenceDetector{}, Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  1.5,
+		Continuous:       true,
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not stop when the audio source ended")
+	}
+	if mockOutput.LastText != "piped audio" {
+		t.Errorf("Expected remaining audio transcribed, got %q", mockOutput.LastText)
+	}
+}
+
+func TestApp_Run_IdleAutoStop(t *testing.T) {
+	config := Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  0.01,
+		Continuous:       true,
+		IdleTimeout:      100 * time.Millisecond, // 1600 samples
+	}
+
+	mockAudio := &mocks.MockAudioCapture{}
+	mockAudio.StartFunc = func(ctx context.Context) (<-chan []float32, error) {
+		audioChan := make(chan []float32)
+		go func() {
+			// Speech first, then silence forever until the app stops reading
+			chunks := [][]float32{{0.5, 0.5, 0.5}}
+			for i := 0; i < 1000; i++ {
+				chunks = append(chunks, make([]float32, 160))
+			}
+			for _, chunk := range chunks {
+				select {
+				case audioChan <- chunk:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return audioChan, nil
+	}
+
+	mockSilence := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool {
+			return samples[0] == 0
+		},
+	}
+	mockTrans := &mocks.MockTranscriber{}
+
+	app := New(mockAudio, mockTrans, &mocks.MockOutput{}, mockSilence, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Continuous mode did not stop after idle timeout")
+	}
+}
+
+func TestApp_isIdle(t *testing.T) {
+	app := &App{config: Config{SampleRate: 16000, Continuous: true, IdleTimeout: time.Second}}
+
+	app.idleSamples = 15999
+	if app.isIdle() {
+		t.Error("Should not be idle before the timeout")
+	}
+	app.idleSamples = 16000
+	if !app.isIdle() {
+		t.Error("Should be idle at the timeout")
+	}
+
+	app.config.Continuous = false
+	if app.isIdle() {
+		t.Error("Idle timeout only applies to continuous mode")
+	}
+
+	app.config.Continuous = true
+	app.config.IdleTimeout = 0
+	if app.isIdle() {
+		t.Error("Zero idle timeout should disable auto-stop")
+	}
+}
+
+func TestApp_Run_MaxSessionDuration(t *testing.T) {
+	config := Config{
+		SampleRate:         16000,
+		SilenceThreshold:   0.01,
+		SilenceDuration:    10, // Never flush on silence during the test
+		Continuous:         true,
+		MaxSessionDuration: 50 * time.Millisecond,
+	}
+
+	mockAudio := &mocks.MockAudioCapture{}
+	mockAudio.StartFunc = func(ctx context.Context) (<-chan []float32, error) {
+		audioChan := make(chan []float32, 1)
+		audioChan <- []float32{0.5, 0.5}
+		return audioChan, nil
+	}
+	mockTrans := &mocks.MockTranscriber{}
+
+	app := New(mockAudio, mockTrans, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, config)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() should end cleanly at max duration, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not stop at max session duration")
+	}
+
+	// Buffered audio is transcribed when the deadline fires
+	if mockTrans.TranscribeCalled != 1 {
+		t.Errorf("Expected remaining buffer to be transcribed once, got %d", mockTrans.TranscribeCalled)
+	}
+}
+
+// abortingTranscriber gives up on its first call when ctx is cancelled, like
+// whisper aborting before encoding, and transcribes later calls normally
+type abortingTranscriber struct {
+	mocks.MockTranscriber
+	calls atomic.Int32
+}
+
+func (a *abortingTranscriber) TranscribeContext(ctx context.Context, audio []float32) (skald.Result, error) {
+	if a.calls.Add(1) == 1 {
+		<-ctx.Done()
+		return skald.Result{}, ctx.Err()
+	}
+	text, err := a.Transcribe(audio)
+	return skald.Result{Text: text}, err
+}
+
+func TestApp_Run_MaxSessionDurationDuringTranscription(t *testing.T) {
+	config := Config{
+		SampleRate:         16000,
+		SilenceThreshold:   0.01,
+		SilenceDuration:    0.01, // The first frame ends the utterance
+		Continuous:         true,
+		MaxSessionDuration: 50 * time.Millisecond,
+	}
+
+	mockAudio := &mocks.MockAudioCapture{}
+	mockAudio.StartFunc = func(ctx context.Context) (<-chan []float32, error) {
+		audioChan := make(chan []float32, 1)
+		audioChan <- make([]float32, 1600)
+		return audioChan, nil
+	}
+	trans := &abortingTranscriber{}
+	trans.TranscribeFunc = func(audio []float32) (string, error) {
+		return "last words", nil
+	}
+	mockOutput := &mocks.MockOutput{}
+	silence := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool { return true },
+	}
+
+	app := New(mockAudio, trans, mockOutput, silence, config)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() should end cleanly at max duration, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not stop at max session duration")
+	}
+
+	// The aborted utterance is kept and flushed when the deadline fires
+	if mockOutput.WriteCalled != 1 || mockOutput.LastText != "last words" {
+		t.Errorf("Expected aborted utterance written once, got %d writes, last %q", mockOutput.WriteCalled, mockOutput.LastText)
+	}
+}
+
+func TestApp_Stop(t *testing.T) {
+	config := Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  10,
+		Continuous:       true,
+	}
+	started := make(chan struct{}, 2)
+	mockAudio := &mocks.MockAudioCapture{}
+	mockAudio.StartFunc = func(ctx context.Context) (<-chan []float32, error) {
+		started <- struct{}{}
+		return make(chan []float32), nil
+	}
+	app := New(mockAudio, &mocks.MockTranscriber{}, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, config)
+
+	app.Stop() // Not running yet, does nothing
+
+	// Run can be stopped and started again
+	for i := 0; i < 2; i++ {
+		done := make(chan error, 1)
+		go func() {
+			done <- app.Run(context.Background())
+		}()
+		<-started
+		app.Stop()
+		app.Stop()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Run() after Stop() = %v, want nil", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Run() did not return after Stop()")
+		}
+	}
+	if mockAudio.StopCalled != 2 {
+		t.Errorf("Audio Stop() called %d times, want 2", mockAudio.StopCalled)
+	}
+}
+
+func TestApp_Run_Once(t *testing.T) {
+	config := Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  0.001,
+		Continuous:       true, // Once ends the run anyway
+		Once:             true,
+	}
+
+	mockAudio := &mocks.MockAudioCapture{}
+	mockAudio.StartFunc = func(ctx context.Context) (<-chan []float32, error) {
+		audioChan := make(chan []float32, 4)
+		audioChan <- []float32{0.5, 0.5}
+		audioChan <- make([]float32, 16)
+		audioChan <- []float32{0.5, 0.5}
+		audioChan <- make([]float32, 16)
+		return audioChan, nil
+	}
+	mockOutput := &mocks.MockOutput{}
+	mockSilence := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool {
+			return samples[0] == 0
+		},
+	}
+	app := New(mockAudio, &mocks.MockTranscriber{}, mockOutput, mockSilence, config)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not stop after the first transcription")
+	}
+	if mockOutput.WriteCalled != 1 {
+		t.Errorf("Expected exactly one transcription written, got %d", mockOutput.WriteCalled)
+	}
+}
+
+// detailedTranscriber reports a fixed language alongside the text
+type detailedTranscriber struct {
+	mocks.MockTranscriber
+	language string
+}
+
+func (d *detailedTranscriber) TranscribeDetailed(audio []float32) (skald.Result, error) {
+	text, err := d.Transcribe(audio)
+	return skald.Result{Text: text, Language: d.language}, err
+}
+
+// metaOutput records metadata passed to WriteMeta
+type metaOutput struct {
+	mocks.MockOutput
+	meta skald.Meta
+}
+
+func (m *metaOutput) WriteMeta(text string, meta skald.Meta) error {
+	m.meta = meta
+	return m.Write(text)
+}
+
+func TestApp_transcribeAndOutput_Metadata(t *testing.T) {
+	trans := &detailedTranscriber{language: "fr"}
+	trans.TranscribeFunc = func(audio []float32) (string, error) {
+		time.Sleep(2 * time.Millisecond)
+		return "Bonjour", nil
+	}
+	out := &metaOutput{}
+
+	app := &App{transcriber: trans, output: out, config: Config{SampleRate: 16000}}
+	if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); err != nil {
+		t.Fatalf("transcribeAndOutput() error = %v", err)
+	}
+
+	if out.LastText != "Bonjour" {
+		t.Errorf("Expected text written, got %q", out.LastText)
+	}
+	if out.meta.Language != "fr" {
+		t.Errorf("Expected detected language passed to output, got %q", out.meta.Language)
+	}
+	if out.meta.Latency < 2*time.Millisecond {
+		t.Errorf("Expected latency passed to output, got %v", out.meta.Latency)
+	}
+}
+
+func TestApp_transcribeAndOutput_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		delay   bool
+		wantErr error
+	}{
+		{"fast transcription within timeout", time.Second, false, nil},
+		{"slow transcription abandoned", 10 * time.Millisecond, true, ErrTranscribeTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTranscriber := &mocks.MockTranscriber{
+				TranscribeFunc: func(audio []float32) (string, error) {
+					if tt.delay {
+						<-release
+					}
+					return "hello", nil
+				},
+			}
+			out := &mocks.MockOutput{}
+			app := New(&mocks.MockAudioCapture{}, mockTranscriber, out, &mocks.MockSilenceDetector{}, Config{
+				SampleRate:        16000,
+				TranscribeTimeout: tt.timeout,
+			})
+
+			err := app.transcribeAndOutput(context.Background(), []float32{0.1})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("transcribeAndOutput() error = %v, want %v", err, tt.wantErr)
+			}
+			wantWrites := 1
+			if tt.wantErr != nil {
+				wantWrites = 0
+			}
+			if out.WriteCalled != wantWrites {
+				t.Errorf("Expected %d writes, got %d", wantWrites, out.WriteCalled)
+			}
+			if tt.wantErr != nil && app.Stats().Errors != 1 {
+				t.Errorf("Expected the timeout recorded as an error, got %+v", app.Stats())
+			}
+		})
+	}
+}
+
+// lateStreamer blocks until released, then streams a segment, like whisper
+// still decoding after its transcription was abandoned
+type lateStreamer struct {
+	mocks.MockTranscriber
+	release chan struct{}
+}
+
+func (l *lateStreamer) TranscribeStream(ctx context.Context, audio []float32, onSegment func(text string)) (skald.Result, error) {
+	<-l.release
+	onSegment("stale")
+	return skald.Result{Text: "stale"}, nil
+}
+
+func TestApp_transcribe_AbandonedCall(t *testing.T) {
+	trans := &lateStreamer{release: make(chan struct{})}
+	out := &partialOutput{}
+	app := New(&mocks.MockAudioCapture{}, trans, out, &mocks.MockSilenceDetector{}, Config{
+		SampleRate:        16000,
+		TranscribeTimeout: 10 * time.Millisecond,
+		StreamSegments:    true,
+	})
+
+	err := app.transcribeAndOutput(context.Background(), []float32{0.1})
+	if !errors.Is(err, ErrTranscribeTimeout) {
+		t.Fatalf("transcribeAndOutput() error = %v, want %v", err, ErrTranscribeTimeout)
+	}
+
+	waited := make(chan struct{})
+	go func() {
+		app.waitAbandoned()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("waitAbandoned() returned while the abandoned call was still running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(trans.release)
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		t.Fatal("waitAbandoned() did not return after the call finished")
+	}
+	if len(out.partials) != 0 || out.WriteCalled != 0 {
+		t.Errorf("Abandoned call should show nothing, got partials %v and %d writes", out.partials, out.WriteCalled)
+	}
+}
+
+func TestApp_transcribe_AbandonedCallDoesNotOverlap(t *testing.T) {
+	var running, peak atomic.Int32
+	mockTranscriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			if n > peak.Load() {
+				peak.Store(n)
+			}
+			time.Sleep(50 * time.Millisecond)
+			return "hello", nil
+		},
+	}
+	app := New(&mocks.MockAudioCapture{}, mockTranscriber, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{
+		SampleRate:        16000,
+		TranscribeTimeout: 10 * time.Millisecond,
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); !errors.Is(err, ErrTranscribeTimeout) {
+			t.Fatalf("transcribeAndOutput() error = %v, want %v", err, ErrTranscribeTimeout)
+		}
+	}
+	app.waitAbandoned()
+
+	if got := peak.Load(); got != 1 {
+		t.Errorf("Peak concurrent transcriptions = %d, want 1", got)
+	}
+	if mockTranscriber.TranscribeCalled != 2 {
+		t.Errorf("Transcribe called %d times, want 2", mockTranscriber.TranscribeCalled)
+	}
+}
+
+// nonSpeechTranscriber returns no text, reporting dropped non-speech segments
+type nonSpeechTranscriber struct {
+	mocks.MockTranscriber
+	nonSpeech int
+}
+
+func (n *nonSpeechTranscriber) TranscribeDetailed(audio []float32) (skald.Result, error) {
+	return skald.Result{NonSpeech: n.nonSpeech}, nil
+}
+
+func TestApp_transcribeAndOutput_EmptyResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		nonSpeech int
+		wantLog   string
+	}{
+		{"nothing recognized", 0, "No speech recognized"},
+		{"only non-speech annotations", 2, "Heard only non-speech audio"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			original := log.Writer()
+			log.SetOutput(&logs)
+			defer log.SetOutput(original)
+
+			trans := &nonSpeechTranscriber{nonSpeech: tt.nonSpeech}
+			out := &mocks.MockOutput{}
+			app := &App{transcriber: trans, output: out, config: Config{SampleRate: 16000}}
+
+			if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); err != nil {
+				t.Fatalf("transcribeAndOutput() error = %v", err)
+			}
+			if out.WriteCalled != 0 {
+				t.Errorf("Expected no output, got %d writes", out.WriteCalled)
+			}
+			if !strings.Contains(logs.String(), tt.wantLog) {
+				t.Errorf("Expected log containing %q, got %q", tt.wantLog, logs.String())
+			}
+		})
+	}
+}
+
+func TestApp_OnTranscription(t *testing.T) {
+	trans := &mocks.MockTranscriber{TranscribeFunc: func(audio []float32) (string, error) {
+		return "hello world", nil
+	}}
+	out := &mocks.MockOutput{}
+	app := New(&mocks.MockAudioCapture{}, trans, out, &mocks.MockSilenceDetector{}, Config{SampleRate: 16000})
+
+	// Without a callback transcription works as before
+	if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); err != nil {
+		t.Fatalf("transcribeAndOutput() error = %v", err)
+	}
+
+	var texts []string
+	var meta skald.Meta
+	app.OnTranscription(func(text string, m skald.Meta) {
+		if out.WriteCalled != 2 {
+			t.Errorf("Expected callback after the output write, writes = %d", out.WriteCalled)
+		}
+		texts = append(texts, text)
+		meta = m
+	})
+	if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); err != nil {
+		t.Fatalf("transcribeAndOutput() error = %v", err)
+	}
+	if len(texts) != 1 || texts[0] != "hello world" {
+		t.Errorf("Callback texts = %q, want [hello world]", texts)
+	}
+	if meta.Latency < 0 {
+		t.Errorf("Expected transcription latency in meta, got %v", meta.Latency)
+	}
+}
+
+func TestIsPunctuationOnly(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{".", true},
+		{"...", true},
+		{" , ", true},
+		{"", true},
+		{"¿?", true},
+		{"Hello.", false},
+		{"42", false},
+		{". a .", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPunctuationOnly(tt.text); got != tt.want {
+			t.Errorf("isPunctuationOnly(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestApp_transcribeAndOutput_SkipPunctuationOnly(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		skip      bool
+		wantWrite int
+	}{
+		{"period skipped", ".", true, 0},
+		{"ellipsis skipped", "...", true, 0},
+		{"comma and spaces skipped", " , ", true, 0},
+		{"words kept", "Hello.", true, 1},
+		{"period kept when disabled", ".", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trans := &mocks.MockTranscriber{TranscribeFunc: func(audio []float32) (string, error) {
+				return tt.text, nil
+			}}
+			out := &mocks.MockOutput{}
+			app := New(&mocks.MockAudioCapture{}, trans, out, &mocks.MockSilenceDetector{}, Config{
+				SampleRate:          16000,
+				SkipPunctuationOnly: tt.skip,
+			})
+
+			if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); err != nil {
+				t.Fatalf("transcribeAndOutput() error = %v", err)
+			}
+			if out.WriteCalled != tt.wantWrite {
+				t.Errorf("Expected %d writes, got %d", tt.wantWrite, out.WriteCalled)
+			}
+		})
+	}
+}
+
+// speechDetector is a silence detector that also implements VAD
+type speechDetector struct {
+	mocks.MockSilenceDetector
+	speech bool
+}
+
+func (d *speechDetector) HasSpeech(samples []float32, sampleRate uint32, threshold float32) bool {
+	return d.speech
+}
+
+func TestApp_transcribeAndOutput_VAD(t *testing.T) {
+	tests := []struct {
+		name           string
+		vadEnabled     bool
+		speech         bool
+		wantTranscribe int
+	}{
+		{"VAD disabled transcribes noise", false, false, 1},
+		{"VAD skips buffer without speech", true, false, 0},
+		{"VAD transcribes speech", true, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTranscriber := &mocks.MockTranscriber{}
+			app := New(&mocks.MockAudioCapture{}, mockTranscriber, &mocks.MockOutput{}, &speechDetector{speech: tt.speech}, Config{
+				SampleRate: 16000,
+				VADEnabled: tt.vadEnabled,
+			})
+
+			if err := app.transcribeAndOutput(context.Background(), make([]float32, 16000)); err != nil {
+				t.Fatalf("transcribeAndOutput() error = %v", err)
+			}
+			if mockTranscriber.TranscribeCalled != tt.wantTranscribe {
+				t.Errorf("Transcribe called %d times, want %d", mockTranscriber.TranscribeCalled, tt.wantTranscribe)
+			}
+			if stats := app.Stats(); stats.Transcriptions != tt.wantTranscribe {
+				t.Errorf("Expected %d recorded transcriptions, got %d", tt.wantTranscribe, stats.Transcriptions)
+			}
+		})
+	}
+}
+
+// trimmingDetector is a silence detector that trims a fixed amount of audio
+type trimmingDetector struct {
+	mocks.MockSilenceDetector
+	keep int
+}
+
+func (d *trimmingDetector) TrimSilence(samples []float32, sampleRate uint32, threshold float32) []float32 {
+	return samples[:d.keep]
+}
+
+func TestApp_transcribeAndOutput_TrimSilence(t *testing.T) {
+	tests := []struct {
+		name           string
+		trim           bool
+		keep           int
+		wantTranscribe int
+		wantSamples    int
+	}{
+		{"trimming disabled", false, 100, 1, 16000},
+		{"trimmed buffer transcribed", true, 100, 1, 100},
+		{"all silence skipped", true, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSamples int
+			mockTranscriber := &mocks.MockTranscriber{
+				TranscribeFunc: func(audio []float32) (string, error) {
+					gotSamples = len(audio)
+					return "text", nil
+				},
+			}
+			app := New(&mocks.MockAudioCapture{}, mockTranscriber, &mocks.MockOutput{}, &trimmingDetector{keep: tt.keep}, Config{
+				SampleRate:  16000,
+				TrimSilence: tt.trim,
+			})
+
+			var logs bytes.Buffer
+			original := log.Writer()
+			log.SetOutput(&logs)
+			defer log.SetOutput(original)
+
+			if err := app.transcribeAndOutput(context.Background(), make([]float32, 16000)); err != nil {
+				t.Fatalf("transcribeAndOutput() error = %v", err)
+			}
+			skipped := strings.Contains(logs.String(), "Skipped 1.0s of audio below the silence threshold")
+			if skipped != (tt.wantTranscribe == 0) {
+				t.Errorf("Skip logged = %v, want %v: %q", skipped, tt.wantTranscribe == 0, logs.String())
+			}
+			if mockTranscriber.TranscribeCalled != tt.wantTranscribe {
+				t.Errorf("Transcribe called %d times, want %d", mockTranscriber.TranscribeCalled, tt.wantTranscribe)
+			}
+			if gotSamples != tt.wantSamples {
+				t.Errorf("Transcriber received %d samples, want %d", gotSamples, tt.wantSamples)
+			}
+		})
+	}
+}
+
+// partialOutput records interim and final results
+type partialOutput struct {
+	mocks.MockOutput
+	partials []string
+}
+
+func (o *partialOutput) WritePartial(text string) error {
+	o.partials = append(o.partials, text)
+	return nil
+}
+
+func TestProcessSession_PartialResults(t *testing.T) {
+	mockTranscriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			return fmt.Sprintf("%d seconds", len(audio)/16000), nil
+		},
+	}
+	out := &partialOutput{}
+	mockSilence := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool {
+			return false
+		},
+	}
+
+	app := New(&mocks.MockAudioCapture{}, mockTranscriber, out, mockSilence, Config{
+		SampleRate:      16000,
+		SilenceDuration: 1,
+		PartialInterval: 2 * time.Second,
+	})
+
+	session := &TranscriptionSession{
+		buffer:          make([]float32, 0),
+		silentThreshold: 16000,
+		maxSamples:      16000 * 25,
+	}
+
+	// Five seconds of speech in one-second frames; closing flushes the final result
+	audioChan := make(chan []float32, 5)
+	for i := 0; i < 5; i++ {
+		audioChan <- make([]float32, 16000)
+	}
+	close(audioChan)
+
+	if err := app.processSession(context.Background(), audioChan, session); err != nil {
+		t.Fatalf("processSession() error = %v", err)
+	}
+
+	wantPartials := []string{"2 seconds", "4 seconds"}
+	if fmt.Sprint(out.partials) != fmt.Sprint(wantPartials) {
+		t.Errorf("Partials = %v, want %v", out.partials, wantPartials)
+	}
+	if out.WriteCalled != 1 || out.LastText != "5 seconds" {
+		t.Errorf("Expected one final write of %q, got %d writes, last %q", "5 seconds", out.WriteCalled, out.LastText)
+	}
+	if stats := app.Stats(); stats.Transcriptions != 1 {
+		t.Errorf("Partial results should not be counted, got %d transcriptions", stats.Transcriptions)
+	}
+}
+
+// streamingTranscriber streams fixed segments before returning the whole text
+type streamingTranscriber struct {
+	mocks.MockTranscriber
+	segments []string
+}
+
+func (s *streamingTranscriber) TranscribeStream(ctx context.Context, audio []float32, onSegment func(text string)) (skald.Result, error) {
+	for _, segment := range s.segments {
+		onSegment(segment)
+	}
+	return skald.Result{Text: strings.TrimSpace(strings.Join(s.segments, ""))}, nil
+}
+
+func TestApp_transcribeAndOutput_StreamSegments(t *testing.T) {
+	tests := []struct {
+		name         string
+		stream       bool
+		wantPartials []string
+	}{
+		{"streaming shows segments as they arrive", true, []string{"Hello", "Hello world."}},
+		{"streaming disabled writes whole result only", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trans := &streamingTranscriber{segments: []string{" Hello", " world."}}
+			trans.TranscribeFunc = func(audio []float32) (string, error) {
+				return "Hello world.", nil
+			}
+			out := &partialOutput{}
+			app := New(&mocks.MockAudioCapture{}, trans, out, &mocks.MockSilenceDetector{}, Config{
+				SampleRate:     16000,
+				StreamSegments: tt.stream,
+			})
+
+			if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); err != nil {
+				t.Fatalf("transcribeAndOutput() error = %v", err)
+			}
+			if fmt.Sprint(out.partials) != fmt.Sprint(tt.wantPartials) {
+				t.Errorf("Partials = %q, want %q", out.partials, tt.wantPartials)
+			}
+			if out.LastText != "Hello world." {
+				t.Errorf("Final text = %q, want %q", out.LastText, "Hello world.")
+			}
+		})
+	}
+}
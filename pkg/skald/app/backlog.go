@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"log"
+
+	"skald/pkg/skald"
+)
+
+// limitBacklog relays audio frames from in, queueing them while the session
+// is busy transcribing. When more than MaxLatency of audio is queued the
+// oldest frames are dropped, trading lost audio for output that stays close
+// to real time on hardware that cannot keep up.
+func (app *App) limitBacklog(ctx context.Context, in <-chan []float32) <-chan []float32 {
+	maxSamples := int(app.config.MaxLatency.Seconds() * float64(app.config.SampleRate))
+	out := make(chan []float32)
+
+	app.workers.Add(1)
+	go func() {
+		defer app.workers.Done()
+		defer close(out)
+
+		var queue [][]float32
+		queued := 0
+		behind := false
+
+		for in != nil || len(queue) > 0 {
+			// Only offer a frame when one is queued; a nil channel never sends
+			var send chan<- []float32
+			var next []float32
+			if len(queue) > 0 {
+				send = out
+				next = queue[0]
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case frame, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				queue = append(queue, frame)
+				queued += len(frame)
+
+				dropped := 0
+				for queued > maxSamples && len(queue) > 1 {
+					dropped += len(queue[0])
+					queued -= len(queue[0])
+					queue = queue[1:]
+				}
+				if dropped > 0 && !behind {
+					log.Printf("Warning: transcription is falling behind, dropping audio older than %v", app.config.MaxLatency)
+					behind = true
+				}
+			case send <- next:
+				queue = queue[1:]
+				queued -= len(next)
+				if queued == 0 {
+					behind = false
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// isLive reports whether the capture delivers audio in real time. Only live
+// audio can fall behind; file and pipe input wait for the session instead.
+func (app *App) isLive() bool {
+	live, ok := app.audio.(skald.LiveCapture)
+	return !ok || live.Live()
+}
+
+// reportDroppedFrames logs frames the capture device dropped since the last
+// report. Frames are dropped while a transcription blocks the session, and
+// show up to users as missing words.
+func (app *App) reportDroppedFrames() {
+	counter, ok := app.audio.(skald.FrameDropCounter)
+	if !ok {
+		return
+	}
+	dropped := counter.DroppedFrames()
+	if dropped > app.reportedDrops {
+		log.Printf("Warning: %d audio frames dropped while transcribing", dropped-app.reportedDrops)
+		app.reportedDrops = dropped
+	}
+}
@@ -0,0 +1,244 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"skald/pkg/skald/mocks"
+)
+
+func TestApp_limitBacklog_DropsOldest(t *testing.T) {
+	app := &App{config: Config{SampleRate: 16000, MaxLatency: 300 * time.Millisecond}}
+
+	// Ten 100ms frames queue up while nothing is consuming
+	in := make(chan []float32, 10)
+	for i := 0; i < 10; i++ {
+		frame := make([]float32, 1600)
+		frame[0] = float32(i)
+		in <- frame
+	}
+	close(in)
+
+	out := app.limitBacklog(context.Background(), in)
+	time.Sleep(50 * time.Millisecond) // Let the relay drain the input
+
+	var got []float32
+	for frame := range out {
+		got = append(got, frame[0])
+	}
+
+	want := []float32{7, 8, 9}
+	if len(got) != len(want) {
+		t.Fatalf("Expected frames %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Frame %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestApp_limitBacklog_PassesThroughWhenKeepingUp(t *testing.T) {
+	app := &App{config: Config{SampleRate: 16000, MaxLatency: 100 * time.Millisecond}}
+
+	in := make(chan []float32)
+	out := app.limitBacklog(context.Background(), in)
+
+	for i := 0; i < 5; i++ {
+		in <- make([]float32, 1600)
+		select {
+		case frame := <-out:
+			if len(frame) != 1600 {
+				t.Errorf("Frame %d has %d samples, want 1600", i, len(frame))
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Frame %d was not relayed", i)
+		}
+	}
+	close(in)
+
+	if _, ok := <-out; ok {
+		t.Error("Expected output to close after input closes")
+	}
+}
+
+func TestApp_limitBacklog_StopsOnCancel(t *testing.T) {
+	app := &App{config: Config{SampleRate: 16000, MaxLatency: time.Second}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := app.limitBacklog(ctx, make(chan []float32))
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Error("Expected no frames after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Relay did not stop on cancel")
+	}
+}
+
+func TestApp_Run_StopsBacklogRelay(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	// A spoken stop command ends the run while frames are still queued and
+	// the capture channel is open
+	audioChan := make(chan []float32, 10)
+	speech := make([]float32, 1600)
+	for i := range speech {
+		speech[i] = 0.5
+	}
+	audioChan <- speech
+	for i := 0; i < 9; i++ {
+		audioChan <- make([]float32, 1600)
+	}
+
+	capture := &mocks.MockAudioCapture{
+		StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+			return audioChan, nil
+		},
+	}
+	detector := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool {
+			return samples[0] < threshold
+		},
+	}
+	transcriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			return "stop", nil
+		},
+	}
+	app := New(capture, transcriber, &mocks.MockOutput{}, detector, Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  0.2,
+		VoiceCommands:    map[string]string{"stop": "stop"},
+		MaxLatency:       time.Second,
+	})
+
+	if err := app.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > baseline {
+		t.Errorf("Expected background goroutines to stop with Run, %d still running", n-baseline)
+	}
+}
+
+// offlineCapture is a capture whose audio does not arrive in real time
+type offlineCapture struct {
+	mocks.MockAudioCapture
+}
+
+func (c *offlineCapture) Live() bool {
+	return false
+}
+
+func TestApp_Run_KeepsBacklogOfOfflineInput(t *testing.T) {
+	audioChan := make(chan []float32, 10)
+	for i := 0; i < 5; i++ {
+		speech := make([]float32, 1600)
+		for j := range speech {
+			speech[j] = 0.5
+		}
+		audioChan <- speech
+	}
+	for i := 0; i < 5; i++ {
+		audioChan <- make([]float32, 1600)
+	}
+	close(audioChan)
+
+	capture := &offlineCapture{mocks.MockAudioCapture{
+		StartFunc: func(ctx context.Context) (<-chan []float32, error) {
+			return audioChan, nil
+		},
+	}}
+	// Stalling on the first frame lets a backlog build up behind it
+	var stalled atomic.Bool
+	detector := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool {
+			if stalled.CompareAndSwap(false, true) {
+				time.Sleep(50 * time.Millisecond)
+			}
+			return samples[0] < threshold
+		},
+	}
+	var speechSamples atomic.Int64
+	transcriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			for _, sample := range audio {
+				if sample > 0 {
+					speechSamples.Add(1)
+				}
+			}
+			return "hello", nil
+		},
+	}
+	app := New(capture, transcriber, &mocks.MockOutput{}, detector, Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  0.2,
+		MaxLatency:       10 * time.Millisecond,
+	})
+
+	if err := app.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := speechSamples.Load(); got != 5*1600 {
+		t.Errorf("Transcribed %d speech samples, want %d", got, 5*1600)
+	}
+}
+
+// dropCountingCapture is a capture that reports dropped frames
+type dropCountingCapture struct {
+	mocks.MockAudioCapture
+	dropped uint64
+}
+
+func (c *dropCountingCapture) DroppedFrames() uint64 {
+	return c.dropped
+}
+
+func TestApp_reportDroppedFrames(t *testing.T) {
+	var logs bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(&logs)
+	defer log.SetOutput(original)
+
+	capture := &dropCountingCapture{}
+	app := &App{audio: capture}
+
+	app.reportDroppedFrames()
+	if logs.Len() != 0 {
+		t.Errorf("Expected no warning without drops, got %q", logs.String())
+	}
+
+	capture.dropped = 4
+	app.reportDroppedFrames()
+	if !strings.Contains(logs.String(), "4 audio frames dropped") {
+		t.Errorf("Expected drop warning, got %q", logs.String())
+	}
+
+	// Only new drops are reported
+	logs.Reset()
+	app.reportDroppedFrames()
+	if logs.Len() != 0 {
+		t.Errorf("Expected no repeated warning, got %q", logs.String())
+	}
+	capture.dropped = 6
+	app.reportDroppedFrames()
+	if !strings.Contains(logs.String(), "2 audio frames dropped") {
+		t.Errorf("Expected warning for 2 new drops, got %q", logs.String())
+	}
+}
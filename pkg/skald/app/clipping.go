@@ -0,0 +1,19 @@
+package app
+
+import (
+	"log"
+
+	"skald/pkg/skald/audio"
+)
+
+// clipWarnFraction is the share of clipped samples in a buffer above which
+// the input gain is reported as too high
+const clipWarnFraction = 0.001
+
+// warnClipping logs a warning when buffer clips often enough to hurt
+// transcription, so the user knows to lower the microphone gain
+func warnClipping(buffer []float32) {
+	if fraction := audio.ClippedFraction(buffer); fraction > clipWarnFraction {
+		log.Printf("Warning: %.1f%% of the audio is clipped; lower the microphone gain for better accuracy", fraction*100)
+	}
+}
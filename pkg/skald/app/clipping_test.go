@@ -0,0 +1,33 @@
+package app
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestWarnClipping(t *testing.T) {
+	tests := []struct {
+		name     string
+		buffer   []float32
+		wantWarn bool
+	}{
+		{"clean audio", []float32{0.1, -0.3, 0.5, -0.2}, false},
+		{"clipped audio", []float32{1, -1, 0.5, 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			original := log.Writer()
+			log.SetOutput(&logs)
+			defer log.SetOutput(original)
+
+			warnClipping(tt.buffer)
+			if got := strings.Contains(logs.String(), "clipped"); got != tt.wantWarn {
+				t.Errorf("Expected warning %v, got log %q", tt.wantWarn, logs.String())
+			}
+		})
+	}
+}
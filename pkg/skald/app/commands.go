@@ -0,0 +1,116 @@
+package app
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Voice command actions that can be mapped to spoken phrases
+const (
+	ActionStop      = "stop"
+	ActionNewline   = "newline"
+	ActionParagraph = "paragraph"
+)
+
+// IsValidVoiceAction reports whether action is a known voice command action
+func IsValidVoiceAction(action string) bool {
+	switch action {
+	case ActionStop, ActionNewline, ActionParagraph:
+		return true
+	}
+	return false
+}
+
+// voiceCommand is a configured trigger phrase compiled for matching
+type voiceCommand struct {
+	pattern *regexp.Regexp
+	action  string
+}
+
+// compileVoiceCommands builds the patterns for the configured phrases once,
+// longest first so "stop dictation now" wins over "stop dictation"
+func compileVoiceCommands(commands map[string]string) []voiceCommand {
+	phrases := make([]string, 0, len(commands))
+	for phrase := range commands {
+		if strings.TrimSpace(phrase) != "" {
+			phrases = append(phrases, phrase)
+		}
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if len(phrases[i]) != len(phrases[j]) {
+			return len(phrases[i]) > len(phrases[j])
+		}
+		return phrases[i] < phrases[j]
+	})
+
+	compiled := make([]voiceCommand, len(phrases))
+	for i, phrase := range phrases {
+		compiled[i] = voiceCommand{pattern: phrasePattern(phrase), action: commands[phrase]}
+	}
+	return compiled
+}
+
+// applyVoiceCommands replaces trigger phrases in text with their action's
+// output and reports whether a stop command was spoken.
+// Matching is case-insensitive and ignores punctuation whisper adds around the phrase.
+func applyVoiceCommands(text string, commands []voiceCommand) (string, bool) {
+	if len(commands) == 0 || text == "" {
+		return text, false
+	}
+
+	stop := false
+	for _, command := range commands {
+		if !command.pattern.MatchString(text) {
+			continue
+		}
+
+		replacement := " "
+		switch command.action {
+		case ActionStop:
+			stop = true
+		case ActionNewline:
+			replacement = "\n"
+		case ActionParagraph:
+			replacement = "\n\n"
+		}
+		text = command.pattern.ReplaceAllLiteralString(text, replacement)
+	}
+
+	return tidyCommandText(text), stop
+}
+
+// phrasePattern builds a case-insensitive regexp for a phrase, tolerating
+// any whitespace or commas between words and trailing punctuation. Word
+// boundaries are only required where the phrase starts or ends with a
+// letter or digit, since \b cannot match next to punctuation such as the
+// "(" in "(comma".
+func phrasePattern(phrase string) *regexp.Regexp {
+	words := strings.Fields(phrase)
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	pattern := strings.Join(words, `[\s,]+`)
+	trimmed := strings.TrimSpace(phrase)
+	if isWordByte(trimmed[0]) {
+		pattern = `\b` + pattern
+	}
+	if isWordByte(trimmed[len(trimmed)-1]) {
+		pattern += `\b`
+	}
+	return regexp.MustCompile(`(?i)` + pattern + `[.,!?;:]*`)
+}
+
+// isWordByte reports whether b is an ASCII word character, as \b sees it
+func isWordByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// tidyCommandText collapses the whitespace left behind by removed phrases
+func tidyCommandText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), " ")
+}
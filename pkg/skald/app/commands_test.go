@@ -0,0 +1,143 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"skald/pkg/skald/mocks"
+)
+
+func TestApplyVoiceCommands(t *testing.T) {
+	commands := compileVoiceCommands(map[string]string{
+		"stop dictation": ActionStop,
+		"new line":       ActionNewline,
+		"new paragraph":  ActionParagraph,
+		"(comma":         ActionNewline,
+		"next item.":     ActionParagraph,
+	})
+
+	tests := []struct {
+		name         string
+		text         string
+		expectedText string
+		expectedStop bool
+	}{
+		{
+			name:         "no commands in text",
+			text:         "Hello world",
+			expectedText: "Hello world",
+		},
+		{
+			name:         "stop command removed",
+			text:         "Send the report. Stop dictation.",
+			expectedText: "Send the report.",
+			expectedStop: true,
+		},
+		{
+			name:         "only stop command",
+			text:         "Stop dictation",
+			expectedText: "",
+			expectedStop: true,
+		},
+		{
+			name:         "newline inserted",
+			text:         "First item, new line, second item",
+			expectedText: "First item,\nsecond item",
+		},
+		{
+			name:         "paragraph inserted",
+			text:         "End of intro. New paragraph. Body starts here.",
+			expectedText: "End of intro.\n\nBody starts here.",
+		},
+		{
+			name:         "case insensitive",
+			text:         "hello NEW LINE world",
+			expectedText: "hello\nworld",
+		},
+		{
+			name:         "phrase starting with punctuation",
+			text:         "Apples (comma pears",
+			expectedText: "Apples\npears",
+		},
+		{
+			name:         "phrase ending with punctuation",
+			text:         "Apples next item. Pears",
+			expectedText: "Apples\n\nPears",
+		},
+		{
+			name:         "partial word not matched",
+			text:         "The renew linear model",
+			expectedText: "The renew linear model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, stop := applyVoiceCommands(tt.text, commands)
+			if text != tt.expectedText {
+				t.Errorf("applyVoiceCommands() text = %q, want %q", text, tt.expectedText)
+			}
+			if stop != tt.expectedStop {
+				t.Errorf("applyVoiceCommands() stop = %v, want %v", stop, tt.expectedStop)
+			}
+		})
+	}
+}
+
+func TestApplyVoiceCommands_NoCommands(t *testing.T) {
+	text, stop := applyVoiceCommands("  stop dictation  ", nil)
+	if text != "  stop dictation  " || stop {
+		t.Errorf("Expected text untouched without commands, got %q (stop=%v)", text, stop)
+	}
+}
+
+func TestApp_Run_VoiceStopEndsContinuousMode(t *testing.T) {
+	config := Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  0.001,
+		Continuous:       true,
+		VoiceCommands:    map[string]string{"stop listening": ActionStop},
+	}
+
+	mockAudio := &mocks.MockAudioCapture{}
+	audioChan := make(chan []float32, 10)
+	mockAudio.StartFunc = func(ctx context.Context) (<-chan []float32, error) {
+		audioChan <- []float32{0.5, 0.5}
+		audioChan <- make([]float32, 16)
+		return audioChan, nil
+	}
+
+	mockTrans := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			return "That is all. Stop listening.", nil
+		},
+	}
+	mockOutput := &mocks.MockOutput{}
+	mockSilence := &mocks.MockSilenceDetector{
+		IsSilentFunc: func(samples []float32, threshold float32) bool {
+			return samples[0] == 0
+		},
+	}
+
+	app := New(mockAudio, mockTrans, mockOutput, mockSilence, config)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not stop after spoken stop command")
+	}
+
+	if mockOutput.LastText != "That is all." {
+		t.Errorf("Expected stop phrase removed from output, got %q", mockOutput.LastText)
+	}
+}
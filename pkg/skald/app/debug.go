@@ -0,0 +1,49 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"skald/pkg/skald/audio"
+)
+
+// saveAudio writes buffer to a timestamped WAV file in SaveAudioDir so a
+// wrong transcription can be checked against the audio that produced it.
+// The file name is logged, and failures are logged without stopping the run.
+func (app *App) saveAudio(buffer []float32) {
+	if app.config.SaveAudioDir == "" {
+		return
+	}
+	path, err := writeAudioFile(app.config.SaveAudioDir, time.Now(), buffer, app.config.SampleRate)
+	if err != nil {
+		log.Printf("Failed to save audio: %v", err)
+		return
+	}
+	log.Printf("Saved audio to %s", path)
+}
+
+// writeAudioFile writes samples to dir as skald-<timestamp>.wav and returns its path
+func writeAudioFile(dir string, now time.Time, samples []float32, sampleRate uint32) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create audio directory: %w", err)
+	}
+
+	name := "skald-" + now.Format("20060102-150405.000") + ".wav"
+	path := filepath.Join(dir, name)
+	file, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create audio file: %w", err)
+	}
+
+	if err := audio.WriteWAV(file, samples, sampleRate); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to close audio file: %w", err)
+	}
+	return path, nil
+}
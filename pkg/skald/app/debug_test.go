@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"skald/pkg/skald/audio"
+	"skald/pkg/skald/mocks"
+)
+
+func TestApp_transcribeAndOutput_SaveAudio(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "audio")
+	app := New(&mocks.MockAudioCapture{}, &mocks.MockTranscriber{}, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{
+		SampleRate:   16000,
+		SaveAudioDir: dir,
+	})
+
+	buffer := []float32{0.1, -0.2, 0.3}
+	if err := app.transcribeAndOutput(context.Background(), buffer); err != nil {
+		t.Fatalf("transcribeAndOutput() error = %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "skald-*.wav"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 saved audio file, got %d", len(files))
+	}
+
+	samples, rate, err := audio.DecodeFile(files[0])
+	if err != nil {
+		t.Fatalf("Saved audio is not readable: %v", err)
+	}
+	if rate != 16000 || len(samples) != len(buffer) {
+		t.Errorf("Saved %d samples at %d Hz, want %d at 16000 Hz", len(samples), rate, len(buffer))
+	}
+}
+
+func TestApp_transcribeAndOutput_SaveAudioDisabled(t *testing.T) {
+	dir := t.TempDir()
+	app := New(&mocks.MockAudioCapture{}, &mocks.MockTranscriber{}, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{
+		SampleRate: 16000,
+	})
+
+	if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); err != nil {
+		t.Fatalf("transcribeAndOutput() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no files written, got %d", len(entries))
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// hookPlaceholder in a CommandHook argument is replaced by the transcription
+const hookPlaceholder = "{text}"
+
+// DefaultHookTimeout limits a command hook when CommandTimeout is not set
+const DefaultHookTimeout = 10 * time.Second
+
+// runHook runs the configured command with a transcription in the
+// background, so a slow command does not hold up the audio loop. Commands
+// run one at a time in transcription order, and Run waits for them before
+// returning; a hook is not killed by stopping, only by its timeout.
+// Failures are logged rather than returned, since the text has already been
+// output.
+func (app *App) runHook(ctx context.Context, text string) {
+	if len(app.config.CommandHook) == 0 {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	prev := app.lastHook
+	done := make(chan struct{})
+	app.lastHook = done
+
+	app.workers.Add(1)
+	go func() {
+		defer app.workers.Done()
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		if err := runCommandHook(ctx, app.config.CommandHook, text, app.config.CommandTimeout); err != nil {
+			log.Printf("Warning: command hook failed: %v", err)
+		}
+	}()
+}
+
+// runCommandHook runs command with text substituted for {text} in its
+// arguments. If no argument contains the placeholder the text is passed on
+// stdin instead. The command is killed if it runs longer than timeout.
+func runCommandHook(ctx context.Context, command []string, text string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	args := make([]string, len(command)-1)
+	substituted := false
+	for i, arg := range command[1:] {
+		if strings.Contains(arg, hookPlaceholder) {
+			arg = strings.ReplaceAll(arg, hookPlaceholder, text)
+			substituted = true
+		}
+		args[i] = arg
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], args...)
+	if !substituted {
+		cmd.Stdin = strings.NewReader(text)
+	}
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s timed out after %v", command[0], timeout)
+	}
+	if err != nil {
+		if detail := strings.TrimSpace(string(out)); detail != "" {
+			return fmt.Errorf("%s: %w: %s", command[0], err, detail)
+		}
+		return fmt.Errorf("%s: %w", command[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"skald/internal/logging"
+	"skald/pkg/skald/mocks"
+)
+
+func TestRunCommandHook(t *testing.T) {
+	dir := t.TempDir()
+	outFile := filepath.Join(dir, "out.txt")
+
+	tests := []struct {
+		name    string
+		command []string
+		want    string
+		wantErr string
+	}{
+		{"text as argument", []string{"sh", "-c", `printf '%s' "$1" > "$0"`, outFile, "said: {text}"}, "said: hello world", ""},
+		{"text on stdin", []string{"sh", "-c", `cat > "$0"`, outFile}, "hello world", ""},
+		{"failing command", []string{"sh", "-c", "echo broken >&2; exit 3"}, "", "broken"},
+		{"timeout", []string{"sleep", "5"}, "", "timed out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(outFile)
+			err := runCommandHook(context.Background(), tt.command, "hello world", 100*time.Millisecond)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("runCommandHook() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runCommandHook() error = %v", err)
+			}
+			got, err := os.ReadFile(outFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Hook received %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApp_transcribeAndOutput_CommandHook(t *testing.T) {
+	outFile := filepath.Join(t.TempDir(), "out.txt")
+	mockTranscriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			return "hello", nil
+		},
+	}
+	app := New(&mocks.MockAudioCapture{}, mockTranscriber, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{
+		SampleRate:  16000,
+		CommandHook: []string{"sh", "-c", `cat > "$0"`, outFile},
+	})
+
+	if err := app.transcribeAndOutput(context.Background(), []float32{0.1}); err != nil {
+		t.Fatalf("transcribeAndOutput() error = %v", err)
+	}
+	app.workers.Wait()
+	got, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("Hook did not run: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("Hook received %q, want %q", got, "hello")
+	}
+}
+
+func TestApp_runHook_DoesNotBlock(t *testing.T) {
+	outFile := filepath.Join(t.TempDir(), "out.txt")
+	app := New(&mocks.MockAudioCapture{}, &mocks.MockTranscriber{}, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{
+		SampleRate:  16000,
+		CommandHook: []string{"sh", "-c", `sleep 0.2; cat >> "$0"; echo >> "$0"`, outFile},
+	})
+
+	start := time.Now()
+	app.runHook(context.Background(), "first")
+	app.runHook(context.Background(), "second")
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("runHook() blocked for %v", elapsed)
+	}
+
+	app.workers.Wait()
+	got, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("Hook did not run: %v", err)
+	}
+	if string(got) != "first\nsecond\n" {
+		t.Errorf("Hooks wrote %q, want them in transcription order", got)
+	}
+}
+
+func TestApp_runHook_FailureIsWarning(t *testing.T) {
+	var logs bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(logging.NewQuietWriter(&logs))
+	defer log.SetOutput(original)
+
+	app := New(&mocks.MockAudioCapture{}, &mocks.MockTranscriber{}, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{
+		SampleRate:  16000,
+		CommandHook: []string{"sh", "-c", "exit 1"},
+	})
+	app.runHook(context.Background(), "hello")
+	app.workers.Wait()
+
+	// -quiet drops informational lines, so the failure must be inferred as a warning
+	if !strings.Contains(logs.String(), "Warning: command hook failed") {
+		t.Errorf("Hook failure hidden by -quiet, got %q", logs.String())
+	}
+}
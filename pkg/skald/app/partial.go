@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"skald/pkg/skald"
+)
+
+// partialDue reports whether enough new speech has arrived since the last
+// interim result to show another one
+func (app *App) partialDue(session *TranscriptionSession) bool {
+	if app.config.PartialInterval <= 0 || session.silentSamples > 0 {
+		return false
+	}
+	if _, ok := app.output.(skald.PartialOutput); !ok {
+		return false
+	}
+	interval := int(app.config.PartialInterval.Seconds() * float64(app.config.SampleRate))
+	return len(session.buffer)-session.partialSamples >= interval
+}
+
+// emitPartial transcribes the audio collected so far and shows it as an
+// interim result. The final transcription of the same buffer replaces it, so
+// partial results are not counted in stats and spoken stop commands in them
+// are ignored until the final result.
+func (app *App) emitPartial(ctx context.Context, buffer []float32) {
+	partial, ok := app.output.(skald.PartialOutput)
+	if !ok {
+		return
+	}
+
+	app.transcribing.Store(true)
+	result, err := app.transcribe(ctx, buffer, nil)
+	app.transcribing.Store(false)
+	if err != nil || ctx.Err() != nil {
+		return
+	}
+
+	text, _ := applyVoiceCommands(app.awakeText(result.Text), app.voiceCommands)
+	if text == "" {
+		return
+	}
+	if err := partial.WritePartial(text); err != nil {
+		log.Printf("Partial output error: %v", err)
+	}
+}
+
+// segmentStreamer returns a callback that shows the text decoded so far as
+// an interim result after each segment, or nil if StreamSegments is off or
+// the output cannot show interim results. The final Write replaces it, so
+// clipboard output still receives only the whole result.
+func (app *App) segmentStreamer() func(string) {
+	if !app.config.StreamSegments {
+		return nil
+	}
+	partial, ok := app.output.(skald.PartialOutput)
+	if !ok {
+		return nil
+	}
+
+	// The callback may outlive a timed-out transcription, so it must not
+	// read the wake state while the next transcription updates it, and it
+	// stops showing segments once that transcription has been abandoned
+	awake := app.wake == nil || app.wake.awake
+	generation := app.generation.Load()
+	var segments []string
+	return func(segment string) {
+		if app.generation.Load() != generation {
+			return
+		}
+		if trimmed := strings.TrimSpace(segment); trimmed != "" {
+			segments = append(segments, trimmed)
+		}
+		text := strings.Join(segments, " ")
+		if app.wake != nil {
+			text, _ = app.wake.pass(text, awake)
+		}
+		text, _ = applyVoiceCommands(text, app.voiceCommands)
+		if text == "" {
+			return
+		}
+		if err := partial.WritePartial(text); err != nil {
+			log.Printf("Partial output error: %v", err)
+		}
+	}
+}
+
+// awakeText returns the part of an interim result spoken while the wake
+// gate is open, without changing its state
+func (app *App) awakeText(text string) string {
+	if app.wake == nil {
+		return text
+	}
+	text, _ = app.wake.pass(text, app.wake.awake)
+	return text
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats summarizes transcription latency over the lifetime of an App. Only
+// successful transcriptions count towards the latency and audio totals, so
+// a timeout does not skew the averages.
+type Stats struct {
+	Transcriptions int // Successful transcriptions
+	Errors         int // Failed or timed-out transcriptions
+	MinLatency     time.Duration
+	MaxLatency     time.Duration
+	TotalLatency   time.Duration
+	TotalAudio     time.Duration
+}
+
+// AvgLatency returns the mean wall-clock duration of a transcription call
+func (s Stats) AvgLatency() time.Duration {
+	if s.Transcriptions == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Transcriptions)
+}
+
+// RealtimeFactor returns processing time divided by audio duration.
+// Values below 1 mean the transcriber keeps up with real time.
+func (s Stats) RealtimeFactor() float64 {
+	if s.TotalAudio == 0 {
+		return 0
+	}
+	return float64(s.TotalLatency) / float64(s.TotalAudio)
+}
+
+// statsRecorder accumulates Stats safely across goroutines
+type statsRecorder struct {
+	mu    sync.Mutex
+	stats Stats
+}
+
+// record adds one transcription call to the running totals. A failed call
+// is only counted as an error.
+func (r *statsRecorder) record(latency, audio time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if failed {
+		r.stats.Errors++
+		return
+	}
+
+	if r.stats.Transcriptions == 0 || latency < r.stats.MinLatency {
+		r.stats.MinLatency = latency
+	}
+	if latency > r.stats.MaxLatency {
+		r.stats.MaxLatency = latency
+	}
+	r.stats.Transcriptions++
+	r.stats.TotalLatency += latency
+	r.stats.TotalAudio += audio
+}
+
+// snapshot returns a copy of the current totals
+func (r *statsRecorder) snapshot() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// audioDuration converts a sample count to wall-clock duration
+func audioDuration(samples int, sampleRate uint32) time.Duration {
+	if sampleRate == 0 {
+		return 0
+	}
+	return time.Duration(samples) * time.Second / time.Duration(sampleRate)
+}
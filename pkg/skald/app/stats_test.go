@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"skald/pkg/skald/mocks"
+)
+
+func TestStatsRecorder(t *testing.T) {
+	var r statsRecorder
+
+	if stats := r.snapshot(); stats.AvgLatency() != 0 || stats.RealtimeFactor() != 0 {
+		t.Errorf("Expected zero stats before any record, got %+v", stats)
+	}
+
+	r.record(200*time.Millisecond, time.Second, false)
+	r.record(5*time.Second, time.Second, true) // A timeout must not skew the averages
+	r.record(100*time.Millisecond, time.Second, false)
+	r.record(300*time.Millisecond, time.Second, false)
+
+	stats := r.snapshot()
+	if stats.Transcriptions != 3 {
+		t.Errorf("Transcriptions = %d, want 3", stats.Transcriptions)
+	}
+	if stats.Errors != 1 {
+		t.Errorf("Errors = %d, want 1", stats.Errors)
+	}
+	if stats.MinLatency != 100*time.Millisecond {
+		t.Errorf("MinLatency = %v, want 100ms", stats.MinLatency)
+	}
+	if stats.MaxLatency != 300*time.Millisecond {
+		t.Errorf("MaxLatency = %v, want 300ms", stats.MaxLatency)
+	}
+	if stats.AvgLatency() != 200*time.Millisecond {
+		t.Errorf("AvgLatency() = %v, want 200ms", stats.AvgLatency())
+	}
+	if stats.TotalAudio != 3*time.Second {
+		t.Errorf("TotalAudio = %v, want 3s", stats.TotalAudio)
+	}
+	if rtf := stats.RealtimeFactor(); rtf < 0.199 || rtf > 0.201 {
+		t.Errorf("RealtimeFactor() = %v, want 0.2", rtf)
+	}
+}
+
+func TestAudioDuration(t *testing.T) {
+	if d := audioDuration(16000, 16000); d != time.Second {
+		t.Errorf("audioDuration(16000, 16000) = %v, want 1s", d)
+	}
+	if d := audioDuration(8000, 16000); d != 500*time.Millisecond {
+		t.Errorf("audioDuration(8000, 16000) = %v, want 500ms", d)
+	}
+	if d := audioDuration(100, 0); d != 0 {
+		t.Errorf("audioDuration with zero sample rate = %v, want 0", d)
+	}
+}
+
+func TestApp_transcribeAndOutput_RecordsStats(t *testing.T) {
+	mockTrans := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			time.Sleep(5 * time.Millisecond)
+			return "hello", nil
+		},
+	}
+
+	app := &App{
+		transcriber: mockTrans,
+		output:      &mocks.MockOutput{},
+		config:      Config{SampleRate: 16000},
+	}
+
+	if err := app.transcribeAndOutput(context.Background(), make([]float32, 16000)); err != nil {
+		t.Fatalf("transcribeAndOutput() error = %v", err)
+	}
+
+	stats := app.Stats()
+	if stats.Transcriptions != 1 {
+		t.Errorf("Transcriptions = %d, want 1", stats.Transcriptions)
+	}
+	if stats.TotalAudio != time.Second {
+		t.Errorf("TotalAudio = %v, want 1s", stats.TotalAudio)
+	}
+	if stats.MinLatency < 5*time.Millisecond {
+		t.Errorf("MinLatency = %v, expected at least 5ms", stats.MinLatency)
+	}
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"time"
+
+	"skald/pkg/skald"
+)
+
+// Status is a point-in-time view of what the app is doing
+type Status struct {
+	Transcribing      bool          // A buffer is being transcribed right now
+	Buffered          time.Duration // Audio collected in the current session buffer
+	Level             float32       // RMS of the latest captured frame, 0 if the capture cannot report it
+	LastTranscription time.Time     // When the last transcription finished, zero if none has
+}
+
+// Status returns the current activity. It only reads atomics, so it never
+// waits for an in-flight transcription and is safe to call from any goroutine.
+func (app *App) Status() Status {
+	status := Status{
+		Transcribing: app.transcribing.Load(),
+		Buffered:     audioDuration(int(app.bufferedSamples.Load()), app.config.SampleRate),
+	}
+	if last := app.lastTranscription.Load(); last != 0 {
+		status.LastTranscription = time.Unix(0, last)
+	}
+	if meter, ok := app.audio.(skald.LevelMeter); ok {
+		status.Level = meter.Level()
+	}
+	return status
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"skald/pkg/skald/mocks"
+)
+
+func TestApp_Status(t *testing.T) {
+	var app *App
+	var during Status
+	mockTranscriber := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			during = app.Status()
+			return "hello", nil
+		},
+	}
+
+	app = New(&mocks.MockAudioCapture{}, mockTranscriber, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{
+		SampleRate:       16000,
+		SilenceThreshold: 0.01,
+		SilenceDuration:  1.5,
+	})
+
+	if status := app.Status(); status.Transcribing || status.Buffered != 0 || !status.LastTranscription.IsZero() {
+		t.Errorf("Expected idle status before running, got %+v", status)
+	}
+
+	session := &TranscriptionSession{
+		buffer:          make([]float32, 0),
+		silentThreshold: 24000,
+		maxSamples:      16000 * 25,
+	}
+	audioChan := make(chan []float32, 2)
+	audioChan <- make([]float32, 8000)
+	audioChan <- make([]float32, 8000)
+	close(audioChan)
+
+	if err := app.processSession(context.Background(), audioChan, session); err != nil {
+		t.Fatalf("processSession() error = %v", err)
+	}
+
+	if !during.Transcribing {
+		t.Error("Expected Transcribing to be true during transcription")
+	}
+	if during.Buffered != time.Second {
+		t.Errorf("Expected 1s buffered during transcription, got %v", during.Buffered)
+	}
+	if status := app.Status(); status.Transcribing || status.Buffered != 0 {
+		t.Errorf("Expected idle status after session, got %+v", status)
+	}
+	if app.Status().LastTranscription.IsZero() {
+		t.Error("Expected LastTranscription to be set after a transcription")
+	}
+}
+
+// meteredCapture is a capture that reports an input level
+type meteredCapture struct {
+	mocks.MockAudioCapture
+	level float32
+}
+
+func (c *meteredCapture) Level() float32 {
+	return c.level
+}
+
+func TestApp_Status_Level(t *testing.T) {
+	app := New(&meteredCapture{level: 0.25}, &mocks.MockTranscriber{}, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{SampleRate: 16000})
+	if level := app.Status().Level; level != 0.25 {
+		t.Errorf("Status().Level = %v, want 0.25", level)
+	}
+
+	app = New(&mocks.MockAudioCapture{}, &mocks.MockTranscriber{}, &mocks.MockOutput{}, &mocks.MockSilenceDetector{}, Config{SampleRate: 16000})
+	if level := app.Status().Level; level != 0 {
+		t.Errorf("Status().Level without a meter = %v, want 0", level)
+	}
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"log"
+	"regexp"
+	"strings"
+)
+
+// wakeGate passes on only speech between a wake phrase and a sleep phrase,
+// so skald can listen all the time but only type when addressed. Every
+// utterance is still transcribed while asleep, to listen for the wake phrase.
+type wakeGate struct {
+	wake  *regexp.Regexp
+	sleep *regexp.Regexp // nil without a sleep phrase; once woken the gate stays open
+	awake bool
+}
+
+// newWakeGate returns a gate for the phrases, or nil if wake is empty
+func newWakeGate(wake, sleep string) *wakeGate {
+	if strings.TrimSpace(wake) == "" {
+		return nil
+	}
+	gate := &wakeGate{wake: phrasePattern(wake)}
+	if strings.TrimSpace(sleep) != "" {
+		gate.sleep = phrasePattern(sleep)
+	}
+	return gate
+}
+
+// pass returns the parts of text spoken while awake, starting in the given
+// state, and whether the gate is awake at the end of text. The phrases
+// themselves are removed.
+func (g *wakeGate) pass(text string, awake bool) (string, bool) {
+	var kept []string
+	for text != "" {
+		if !awake {
+			loc := g.wake.FindStringIndex(text)
+			if loc == nil {
+				break
+			}
+			awake = true
+			text = text[loc[1]:]
+			continue
+		}
+
+		var loc []int
+		if g.sleep != nil {
+			loc = g.sleep.FindStringIndex(text)
+		}
+		if loc == nil {
+			kept = append(kept, text)
+			break
+		}
+		kept = append(kept, text[:loc[0]])
+		awake = false
+		text = text[loc[1]:]
+	}
+	return tidyCommandText(strings.Join(kept, " ")), awake
+}
+
+// filter is pass from the gate's current state, which it then updates
+func (g *wakeGate) filter(text string) string {
+	kept, awake := g.pass(text, g.awake)
+	if awake != g.awake {
+		if awake {
+			log.Println("Wake phrase heard, transcribing")
+		} else {
+			log.Println("Sleep phrase heard, waiting for the wake phrase")
+		}
+		g.awake = awake
+	}
+	return kept
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"skald/pkg/skald/mocks"
+)
+
+func TestWakeGate_Pass(t *testing.T) {
+	gate := newWakeGate("hey skald", "go to sleep")
+
+	tests := []struct {
+		name      string
+		text      string
+		awake     bool
+		want      string
+		wantAwake bool
+	}{
+		{"asleep ignores speech", "Just chatting here", false, "", false},
+		{"wake phrase opens the gate", "Hey, Skald. Write this down.", false, "Write this down.", true},
+		{"awake passes speech", "Second sentence.", true, "Second sentence.", true},
+		{"sleep phrase closes the gate", "Last one. Go to sleep.", true, "Last one.", false},
+		{"wake and sleep in one utterance", "Ignored. Hey skald, keep this, go to sleep. Ignored too.", false, "keep this,", false},
+		{"partial word not matched", "They skaldic poems", false, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, awake := gate.pass(tt.text, tt.awake)
+			if got != tt.want || awake != tt.wantAwake {
+				t.Errorf("pass(%q, %v) = %q, %v; want %q, %v", tt.text, tt.awake, got, awake, tt.want, tt.wantAwake)
+			}
+		})
+	}
+}
+
+func TestNewWakeGate(t *testing.T) {
+	if gate := newWakeGate(" ", "go to sleep"); gate != nil {
+		t.Error("Expected no gate without a wake phrase")
+	}
+
+	// Without a sleep phrase the gate stays open once woken
+	gate := newWakeGate("hey skald", "")
+	if got, awake := gate.pass("Hey skald. Go to sleep.", false); got != "Go to sleep." || !awake {
+		t.Errorf("pass() = %q, %v; want %q, true", got, awake, "Go to sleep.")
+	}
+}
+
+func TestApp_transcribeAndOutput_WakeWord(t *testing.T) {
+	texts := []string{"Background talk.", "Hey skald, note one.", "Note two.", "Go to sleep.", "More background."}
+	call := 0
+	mockTrans := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			text := texts[call]
+			call++
+			return text, nil
+		},
+	}
+	mockOutput := &mocks.MockOutput{}
+	config := Config{SampleRate: 16000, WakeWord: "hey skald", SleepWord: "go to sleep"}
+	app := New(&mocks.MockAudioCapture{}, mockTrans, mockOutput, &mocks.MockSilenceDetector{}, config)
+
+	for range texts {
+		if err := app.transcribeAndOutput(context.Background(), []float32{0.5}); err != nil {
+			t.Fatalf("transcribeAndOutput() error = %v", err)
+		}
+	}
+
+	want := []string{"note one.", "Note two."}
+	if len(mockOutput.AllTexts) != len(want) {
+		t.Fatalf("Written = %q, want %q", mockOutput.AllTexts, want)
+	}
+	for i, text := range want {
+		if mockOutput.AllTexts[i] != text {
+			t.Errorf("Written[%d] = %q, want %q", i, mockOutput.AllTexts[i], text)
+		}
+	}
+}
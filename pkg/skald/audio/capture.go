@@ -3,28 +3,140 @@ package audio
 import (
 	"context"
 	"fmt"
+	"log"
+	"math"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/gen2brain/malgo"
 )
 
+// DefaultBufferSize is the number of audio frames queued before new frames are dropped
+const DefaultBufferSize = 100
+
+// Period sizes accepted by SetPeriodSize, in samples
+const (
+	minPeriodSize = 64
+	maxPeriodSize = 16384
+)
+
 // Capture implements audio capture using malgo
 type Capture struct {
-	device     *malgo.Device
-	malgoCtx   *malgo.AllocatedContext
-	sampleRate uint32
-	audioChan  chan []float32
-	mu         sync.Mutex
-	closed     bool
+	device        *malgo.Device
+	malgoCtx      *malgo.AllocatedContext
+	sampleRate    uint32
+	format        string // FormatF32LE or FormatS16LE
+	periodSize    uint32 // Samples per device callback, 0 for the backend default
+	audioChan     chan []float32
+	mu            sync.Mutex
+	closed        bool
+	droppedFrames atomic.Uint64 // Frames discarded because the channel was full
+	level         atomic.Uint32 // float32 bits of the latest frame's RMS
 }
 
 // NewCapture creates a new audio capture instance
 func NewCapture(sampleRate uint32) *Capture {
+	return NewCaptureWithBuffer(sampleRate, DefaultBufferSize)
+}
+
+// NewCaptureWithBuffer creates an audio capture instance that queues up to
+// bufferSize frames for the consumer. Values below 1 use DefaultBufferSize.
+func NewCaptureWithBuffer(sampleRate uint32, bufferSize int) *Capture {
+	if bufferSize < 1 {
+		bufferSize = DefaultBufferSize
+	}
 	return &Capture{
 		sampleRate: sampleRate,
-		audioChan:  make(chan []float32, 100),
+		format:     FormatF32LE,
+		audioChan:  make(chan []float32, bufferSize),
+	}
+}
+
+// ValidateCaptureFormat checks that format is a sample format the capture
+// device can be opened with: FormatF32LE or FormatS16LE
+func ValidateCaptureFormat(format string) error {
+	if format != FormatF32LE && format != FormatS16LE {
+		return fmt.Errorf("unsupported capture format: %q (valid: %s, %s)", format, FormatF32LE, FormatS16LE)
+	}
+	return nil
+}
+
+// SetSampleFormat sets the format the device records in, FormatF32LE by
+// default. Some drivers work more reliably with 16-bit PCM; its samples are
+// converted to float32 before delivery.
+func (a *Capture) SetSampleFormat(format string) error {
+	if err := ValidateCaptureFormat(format); err != nil {
+		return err
+	}
+	a.format = format
+	return nil
+}
+
+// DroppedFrames returns how many frames were discarded because the consumer
+// fell behind and the channel was full
+func (a *Capture) DroppedFrames() uint64 {
+	return a.droppedFrames.Load()
+}
+
+// Level returns the RMS of the most recently captured frame, from 0 (silent)
+// to 1 (full scale). It is safe to poll from any goroutine, e.g. for a level meter.
+func (a *Capture) Level() float32 {
+	return math.Float32frombits(a.level.Load())
+}
+
+// deliver queues samples for the consumer, counting them as dropped if the channel is full
+func (a *Capture) deliver(ctx context.Context, samples []float32) {
+	a.level.Store(math.Float32bits(rms(samples)))
+
+	select {
+	case a.audioChan <- samples:
+	case <-ctx.Done():
+	default:
+		a.droppedFrames.Add(1)
+	}
+}
+
+// SetPeriodSize sets how many samples the device delivers per callback, a
+// power of two from 64 to 16384, or 0 for the audio backend's default.
+// Smaller periods lower latency at the cost of more wake-ups and CPU;
+// larger ones suit slow devices that drop audio with short periods.
+func (a *Capture) SetPeriodSize(samples int) error {
+	if samples != 0 && (samples < minPeriodSize || samples > maxPeriodSize || samples&(samples-1) != 0) {
+		return fmt.Errorf("invalid period size: %d (must be 0 or a power of two from %d to %d)", samples, minPeriodSize, maxPeriodSize)
+	}
+	a.periodSize = uint32(samples) //nolint:gosec // Range checked above
+	return nil
+}
+
+// decodeFrames converts framecount samples from the device to float32,
+// or returns nil if pInput is shorter than framecount samples
+func (a *Capture) decodeFrames(pInput []byte, framecount uint32) []float32 {
+	bytesPerSample := uint32(4)
+	if a.format == FormatS16LE {
+		bytesPerSample = 2
+	}
+
+	// Add bounds checking
+	expectedBytes := framecount * bytesPerSample
+	// Note: Safe conversion after bounds check - len(pInput) is always >= 0
+	if expectedBytes > uint32(len(pInput)) { //nolint:gosec
+		// Log error and skip this frame
+		log.Printf("Warning: Frame count %d exceeds input buffer size %d",
+			framecount, uint32(len(pInput))/bytesPerSample) //nolint:gosec
+		return nil
+	}
+
+	samples := make([]float32, framecount)
+	if a.format == FormatS16LE {
+		for i := range samples {
+			samples[i] = decodeS16LE(pInput[i*2:])
+		}
+		return samples
 	}
+	// Note: Unsafe operation with bounds checking above - required for malgo audio API
+	copy(samples, (*[1 << 30]float32)(unsafe.Pointer(&pInput[0]))[:framecount]) //nolint:gosec
+	return samples
 }
 
 // safeMalgoUninit provides safe cleanup of malgo context with error handling
@@ -34,16 +146,34 @@ func safeMalgoUninit(ctx *malgo.AllocatedContext, operation string) {
 	}
 	if err := ctx.Uninit(); err != nil {
 		// Log but don't fail - cleanup should be best effort
-		fmt.Printf("Warning: Failed to uninit malgo context during %s: %v\n", operation, err)
+		log.Printf("Warning: Failed to uninit malgo context during %s: %v", operation, err)
+	}
+}
+
+// openChannel returns the channel frames are delivered on, replacing the
+// one closed by a previous Stop so the capture can be started again
+func (a *Capture) openChannel() chan []float32 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		a.audioChan = make(chan []float32, cap(a.audioChan))
+		a.closed = false
 	}
+	return a.audioChan
 }
 
-// Start begins audio capture
+// Start begins audio capture. It may be called again after Stop.
 func (a *Capture) Start(ctx context.Context) (<-chan []float32, error) {
+	audioChan := a.openChannel()
+
 	deviceConfig := malgo.DefaultDeviceConfig(malgo.Capture)
 	deviceConfig.Capture.Format = malgo.FormatF32
+	if a.format == FormatS16LE {
+		deviceConfig.Capture.Format = malgo.FormatS16
+	}
 	deviceConfig.Capture.Channels = 1
 	deviceConfig.SampleRate = a.sampleRate
+	deviceConfig.PeriodSizeInFrames = a.periodSize
 	deviceConfig.Alsa.NoMMap = 1
 
 	onRecvFrames := func(pOutput, pInput []byte, framecount uint32) {
@@ -51,27 +181,12 @@ func (a *Capture) Start(ctx context.Context) (<-chan []float32, error) {
 			return
 		}
 		
-		// Add bounds checking
-		expectedBytes := framecount * 4 // 4 bytes per float32
-		// Note: Safe conversion after bounds check - len(pInput) is always >= 0
-		if expectedBytes > uint32(len(pInput)) { //nolint:gosec
-			// Log error and skip this frame
-			fmt.Printf("Warning: Frame count %d exceeds input buffer size %d\n", 
-				framecount, len(pInput)/4)
+		samples := a.decodeFrames(pInput, framecount)
+		if samples == nil {
 			return
 		}
 		
-		samples := make([]float32, framecount)
-		// Note: Unsafe operation with bounds checking above - required for malgo audio API
-		copy(samples, (*[1 << 30]float32)(unsafe.Pointer(&pInput[0]))[:framecount]) //nolint:gosec
-		
-		select {
-		case a.audioChan <- samples:
-		case <-ctx.Done():
-			return
-		default:
-			// Drop frames if channel is full
-		}
+		a.deliver(ctx, samples)
 	}
 
 	malgoCtx, err := malgo.InitContext(nil, malgo.ContextConfig{}, nil)
@@ -85,18 +200,26 @@ func (a *Capture) Start(ctx context.Context) (<-chan []float32, error) {
 	})
 	if err != nil {
 		safeMalgoUninit(malgoCtx, "device init failure cleanup")
+		a.malgoCtx = nil
 		return nil, fmt.Errorf("failed to init capture device: %w", err)
 	}
 
+	// miniaudio resamples a device running at another rate, so frames
+	// should arrive at a.sampleRate; report the negotiated rate if not
+	if rate := device.SampleRate(); rate != a.sampleRate {
+		log.Printf("Capture device opened at %d Hz instead of the requested %d Hz", rate, a.sampleRate)
+	}
 	a.device = device
 
 	if err := device.Start(); err != nil {
 		device.Uninit()
 		safeMalgoUninit(malgoCtx, "device start failure cleanup")
+		a.device = nil
+		a.malgoCtx = nil
 		return nil, fmt.Errorf("failed to start device: %w", err)
 	}
 
-	return a.audioChan, nil
+	return audioChan, nil
 }
 
 // Stop stops audio capture
@@ -96,6 +96,60 @@ func TestCapture_FrameDropping(t *testing.T) {
 	}
 }
 
+func TestCapture_DroppedFrames(t *testing.T) {
+	capture := NewCaptureWithBuffer(16000, 2)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		capture.deliver(ctx, []float32{float32(i)})
+	}
+
+	if got := capture.DroppedFrames(); got != 3 {
+		t.Errorf("DroppedFrames() = %d, want 3", got)
+	}
+	if first := <-capture.audioChan; first[0] != 0 {
+		t.Errorf("Expected oldest queued frame to be kept, got %v", first[0])
+	}
+}
+
+func TestCapture_Level(t *testing.T) {
+	capture := NewCaptureWithBuffer(16000, 1)
+	ctx := context.Background()
+
+	if level := capture.Level(); level != 0 {
+		t.Errorf("Level() before any audio = %v, want 0", level)
+	}
+
+	capture.deliver(ctx, []float32{0.5, -0.5, 0.5, -0.5})
+	if level := capture.Level(); level != 0.5 {
+		t.Errorf("Level() = %v, want 0.5", level)
+	}
+
+	// Dropped frames still update the meter
+	capture.deliver(ctx, []float32{0, 0})
+	if level := capture.Level(); level != 0 {
+		t.Errorf("Level() after silent frame = %v, want 0", level)
+	}
+}
+
+func TestNewCaptureWithBuffer(t *testing.T) {
+	tests := []struct {
+		size int
+		want int
+	}{
+		{size: 10, want: 10},
+		{size: 0, want: DefaultBufferSize},
+		{size: -5, want: DefaultBufferSize},
+	}
+
+	for _, tt := range tests {
+		capture := NewCaptureWithBuffer(16000, tt.size)
+		if got := cap(capture.audioChan); got != tt.want {
+			t.Errorf("NewCaptureWithBuffer(%d) capacity = %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
+
 // TestCapture_OnRecvFramesCallback tests the callback behavior
 func TestCapture_OnRecvFramesCallback(t *testing.T) {
 	t.Run("empty framecount handling", func(t *testing.T) {
@@ -149,6 +203,85 @@ func TestCapture_OnRecvFramesCallback(t *testing.T) {
 	})
 }
 
+func TestCapture_SetSampleFormat(t *testing.T) {
+	capture := NewCapture(16000)
+	if capture.format != FormatF32LE {
+		t.Errorf("Expected default format %s, got %s", FormatF32LE, capture.format)
+	}
+	if err := capture.SetSampleFormat(FormatS16LE); err != nil {
+		t.Fatalf("SetSampleFormat(s16le) error = %v", err)
+	}
+	if capture.format != FormatS16LE {
+		t.Errorf("Expected format %s, got %s", FormatS16LE, capture.format)
+	}
+	if err := capture.SetSampleFormat("u8"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if capture.format != FormatS16LE {
+		t.Errorf("Failed SetSampleFormat should keep %s, got %s", FormatS16LE, capture.format)
+	}
+}
+
+func TestCapture_DecodeFrames(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		input      []byte
+		framecount uint32
+		want       []float32
+	}{
+		{"f32le", FormatF32LE, []byte{0, 0, 0, 0x3f, 0, 0, 0x80, 0xbf}, 2, []float32{0.5, -1}},
+		{"s16le", FormatS16LE, []byte{0, 0x40, 0, 0x80, 0xff, 0x7f}, 3, []float32{0.5, -1, 32767.0 / 32768.0}},
+		{"f32le short input", FormatF32LE, []byte{0, 0, 0, 0x3f}, 2, nil},
+		{"s16le short input", FormatS16LE, []byte{0, 0x40}, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capture := NewCapture(16000)
+			if err := capture.SetSampleFormat(tt.format); err != nil {
+				t.Fatalf("SetSampleFormat() error = %v", err)
+			}
+			got := capture.decodeFrames(tt.input, tt.framecount)
+			if len(got) != len(tt.want) || (got == nil) != (tt.want == nil) {
+				t.Fatalf("decodeFrames() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Sample %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCapture_SetPeriodSize(t *testing.T) {
+	tests := []struct {
+		samples int
+		wantErr bool
+	}{
+		{0, false},
+		{64, false},
+		{512, false},
+		{16384, false},
+		{32, true},
+		{500, true},
+		{32768, true},
+		{-512, true},
+	}
+
+	for _, tt := range tests {
+		capture := NewCapture(16000)
+		err := capture.SetPeriodSize(tt.samples)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SetPeriodSize(%d) error = %v, wantErr %v", tt.samples, err, tt.wantErr)
+		}
+		if err == nil && capture.periodSize != uint32(tt.samples) {
+			t.Errorf("SetPeriodSize(%d) stored %d", tt.samples, capture.periodSize)
+		}
+	}
+}
+
 // TestCapture_MultipleStopCalls verifies Stop() is idempotent
 func TestCapture_MultipleStopCalls(t *testing.T) {
 	capture := NewCapture(16000)
@@ -177,6 +310,32 @@ func TestCapture_MultipleStopCalls(t *testing.T) {
 	}
 }
 
+func TestCapture_RestartAfterStop(t *testing.T) {
+	capture := NewCaptureWithBuffer(16000, 5)
+	first := capture.openChannel()
+	if err := capture.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if _, ok := <-first; ok {
+		t.Fatal("Expected Stop to close the channel")
+	}
+
+	// A restart gets a fresh channel that frames can be delivered on
+	second := capture.openChannel()
+	if second == first || cap(second) != 5 || capture.closed {
+		t.Fatalf("Expected a new open channel with the same buffer size, got cap %d (closed=%v)", cap(second), capture.closed)
+	}
+	capture.deliver(context.Background(), []float32{0.5})
+	if frame := <-second; len(frame) != 1 {
+		t.Errorf("Expected the delivered frame, got %v", frame)
+	}
+
+	// Without a Stop in between the channel is kept
+	if again := capture.openChannel(); again != second {
+		t.Error("Expected the open channel to be reused")
+	}
+}
+
 // TestCapture_DeviceConfigValidation tests device configuration
 func TestCapture_DeviceConfigValidation(t *testing.T) {
 	testCases := []struct {
@@ -41,6 +41,25 @@ func TestCapture_Stop(t *testing.T) {
 	}
 }
 
+func TestCapture_StartAfterStop(t *testing.T) {
+	capture := NewCapture(16000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		audioChan, err := capture.Start(ctx)
+		if err != nil {
+			t.Skip("Audio device not available, skipping test")
+		}
+		if err := capture.Stop(); err != nil {
+			t.Fatalf("Stop() error = %v", err)
+		}
+		for range audioChan {
+			// Drain until Stop's close
+		}
+	}
+}
+
 func TestCapture_StartStop(t *testing.T) {
 	// Skip if audio device is not available
 	capture := NewCapture(16000)
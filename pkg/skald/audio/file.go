@@ -0,0 +1,230 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RawSampleRate is the sample rate assumed for headerless .raw files
+// (32-bit float little-endian mono, as produced by the capture pipeline)
+const RawSampleRate = 16000
+
+// WAV format tags
+const (
+	wavFormatPCM        = 1
+	wavFormatIEEEFloat  = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+// IsSupportedFile reports whether path has an audio extension DecodeFile understands
+func IsSupportedFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wav", ".raw":
+		return true
+	}
+	return false
+}
+
+// DecodeFile reads a .wav or .raw audio file into mono float32 samples
+func DecodeFile(path string) ([]float32, uint32, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open audio file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wav":
+		return ReadWAV(reader)
+	case ".raw":
+		samples, err := ReadRaw(reader)
+		return samples, RawSampleRate, err
+	}
+	return nil, 0, fmt.Errorf("unsupported audio file type: %s", filepath.Ext(path))
+}
+
+// ReadSamples decodes a WAV or raw float32 stream into mono samples at
+// RawSampleRate, the 16kHz whisper expects. WAV streams (16-bit PCM or 32-bit
+// float) are detected by their RIFF header and must already be at that rate;
+// anything else is read as raw 32-bit float little-endian samples.
+func ReadSamples(r io.Reader) ([]float32, error) {
+	reader := bufio.NewReader(r)
+	header, err := reader.Peek(4)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	if bytes.Equal(header, []byte("RIFF")) {
+		samples, rate, err := ReadWAV(reader)
+		if err != nil {
+			return nil, err
+		}
+		if rate != RawSampleRate {
+			return nil, fmt.Errorf("unsupported sample rate %d Hz (expected %d Hz)", rate, RawSampleRate)
+		}
+		return samples, nil
+	}
+
+	return ReadRaw(reader)
+}
+
+// ReadRaw reads headerless 32-bit float little-endian samples until EOF
+func ReadRaw(r io.Reader) ([]float32, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read raw audio: %w", err)
+	}
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("raw audio length %d is not a multiple of 4 bytes", len(data))
+	}
+
+	samples := make([]float32, len(data)/4)
+	for i := range samples {
+		samples[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return samples, nil
+}
+
+// ReadWAV decodes a 16-bit PCM or 32-bit float WAV stream.
+// Multi-channel audio is downmixed to mono by averaging channels.
+func ReadWAV(r io.Reader) ([]float32, uint32, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, 0, fmt.Errorf("failed to read WAV header: %w", err)
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return nil, 0, errors.New("not a RIFF/WAVE file")
+	}
+
+	var (
+		format        uint16
+		channels      uint16
+		sampleRate    uint32
+		bitsPerSample uint16
+		haveFormat    bool
+	)
+
+	for {
+		var chunkHeader [8]byte
+		if _, err := io.ReadFull(r, chunkHeader[:]); err != nil {
+			return nil, 0, errors.New("WAV file has no data chunk")
+		}
+		chunkID := string(chunkHeader[0:4])
+		chunkSize := binary.LittleEndian.Uint32(chunkHeader[4:8])
+
+		switch chunkID {
+		case "fmt ":
+			if chunkSize < 16 {
+				return nil, 0, fmt.Errorf("WAV fmt chunk too small: %d bytes", chunkSize)
+			}
+			fmtData := make([]byte, chunkSize)
+			if _, err := io.ReadFull(r, fmtData); err != nil {
+				return nil, 0, fmt.Errorf("failed to read WAV fmt chunk: %w", err)
+			}
+			format = binary.LittleEndian.Uint16(fmtData[0:2])
+			channels = binary.LittleEndian.Uint16(fmtData[2:4])
+			sampleRate = binary.LittleEndian.Uint32(fmtData[4:8])
+			bitsPerSample = binary.LittleEndian.Uint16(fmtData[14:16])
+			if format == wavFormatExtensible && chunkSize >= 26 {
+				format = binary.LittleEndian.Uint16(fmtData[24:26])
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, 0, errors.New("WAV data chunk before fmt chunk")
+			}
+			samples, err := decodeWAVData(io.LimitReader(r, int64(chunkSize)), format, channels, bitsPerSample)
+			if err != nil {
+				return nil, 0, err
+			}
+			return samples, sampleRate, nil
+		default:
+			// Skip unknown chunks (LIST, fact, ...); chunks are padded to even sizes
+			if _, err := io.CopyN(io.Discard, r, int64(chunkSize)+int64(chunkSize%2)); err != nil {
+				return nil, 0, fmt.Errorf("failed to skip WAV chunk %q: %w", chunkID, err)
+			}
+		}
+	}
+}
+
+// WriteWAV encodes mono samples as a 32-bit float WAV stream, which ReadWAV
+// reads back without loss
+func WriteWAV(w io.Writer, samples []float32, sampleRate uint32) error {
+	dataSize := uint32(len(samples) * 4) //nolint:gosec
+
+	var header [44]byte
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], 36+dataSize)
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16)
+	binary.LittleEndian.PutUint16(header[20:22], wavFormatIEEEFloat)
+	binary.LittleEndian.PutUint16(header[22:24], 1) // Mono
+	binary.LittleEndian.PutUint32(header[24:28], sampleRate)
+	binary.LittleEndian.PutUint32(header[28:32], sampleRate*4) // Byte rate
+	binary.LittleEndian.PutUint16(header[32:34], 4)            // Block align
+	binary.LittleEndian.PutUint16(header[34:36], 32)           // Bits per sample
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], dataSize)
+
+	data := make([]byte, len(samples)*4)
+	for i, sample := range samples {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(sample))
+	}
+
+	if _, err := w.Write(header[:]); err != nil {
+		return fmt.Errorf("failed to write WAV header: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write WAV data: %w", err)
+	}
+	return nil
+}
+
+// decodeWAVData converts interleaved WAV sample data to mono float32
+func decodeWAVData(r io.Reader, format, channels, bitsPerSample uint16) ([]float32, error) {
+	if channels == 0 {
+		return nil, errors.New("WAV file has zero channels")
+	}
+
+	var decode func([]byte) float32
+	switch {
+	case format == wavFormatPCM && bitsPerSample == 16:
+		decode = func(b []byte) float32 {
+			return float32(int16(binary.LittleEndian.Uint16(b))) / 32768.0 //nolint:gosec
+		}
+	case format == wavFormatIEEEFloat && bitsPerSample == 32:
+		decode = func(b []byte) float32 {
+			return math.Float32frombits(binary.LittleEndian.Uint32(b))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported WAV encoding: format %d, %d bits", format, bitsPerSample)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WAV data: %w", err)
+	}
+
+	bytesPerSample := int(bitsPerSample / 8)
+	frameSize := bytesPerSample * int(channels)
+	samples := make([]float32, len(data)/frameSize)
+	for i := range samples {
+		var sum float32
+		for ch := 0; ch < int(channels); ch++ {
+			offset := i*frameSize + ch*bytesPerSample
+			sum += decode(data[offset : offset+bytesPerSample])
+		}
+		samples[i] = sum / float32(channels)
+	}
+	return samples, nil
+}
@@ -0,0 +1,233 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildWAV assembles a WAV stream with the given format and raw sample data
+func buildWAV(format, channels uint16, sampleRate uint32, bitsPerSample uint16, data []byte, extraChunk bool) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // Size is not validated
+	buf.WriteString("WAVE")
+
+	if extraChunk {
+		buf.WriteString("LIST")
+		binary.Write(&buf, binary.LittleEndian, uint32(3))
+		buf.Write([]byte{1, 2, 3, 0}) // Odd size plus pad byte
+	}
+
+	buf.WriteString("fmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, format)
+	binary.Write(&buf, binary.LittleEndian, channels)
+	binary.Write(&buf, binary.LittleEndian, sampleRate)
+	blockAlign := channels * bitsPerSample / 8
+	binary.Write(&buf, binary.LittleEndian, sampleRate*uint32(blockAlign))
+	binary.Write(&buf, binary.LittleEndian, blockAlign)
+	binary.Write(&buf, binary.LittleEndian, bitsPerSample)
+
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func int16Bytes(values ...int16) []byte {
+	var buf bytes.Buffer
+	for _, v := range values {
+		binary.Write(&buf, binary.LittleEndian, v)
+	}
+	return buf.Bytes()
+}
+
+func float32Bytes(values ...float32) []byte {
+	var buf bytes.Buffer
+	for _, v := range values {
+		binary.Write(&buf, binary.LittleEndian, math.Float32bits(v))
+	}
+	return buf.Bytes()
+}
+
+func TestReadWAV(t *testing.T) {
+	tests := []struct {
+		name         string
+		wav          []byte
+		expected     []float32
+		expectedRate uint32
+		expectError  bool
+	}{
+		{
+			name:         "16-bit PCM mono",
+			wav:          buildWAV(wavFormatPCM, 1, 16000, 16, int16Bytes(0, 16384, -32768), false),
+			expected:     []float32{0, 0.5, -1},
+			expectedRate: 16000,
+		},
+		{
+			name:         "32-bit float mono",
+			wav:          buildWAV(wavFormatIEEEFloat, 1, 16000, 32, float32Bytes(0.25, -0.75), false),
+			expected:     []float32{0.25, -0.75},
+			expectedRate: 16000,
+		},
+		{
+			name:         "stereo downmixed to mono",
+			wav:          buildWAV(wavFormatPCM, 2, 44100, 16, int16Bytes(16384, 0, -16384, -16384), false),
+			expected:     []float32{0.25, -0.5},
+			expectedRate: 44100,
+		},
+		{
+			name:         "unknown chunks skipped",
+			wav:          buildWAV(wavFormatPCM, 1, 16000, 16, int16Bytes(16384), true),
+			expected:     []float32{0.5},
+			expectedRate: 16000,
+		},
+		{
+			name:        "unsupported bit depth",
+			wav:         buildWAV(wavFormatPCM, 1, 16000, 24, []byte{0, 0, 0}, false),
+			expectError: true,
+		},
+		{
+			name:        "not a WAV file",
+			wav:         []byte("this is not audio at all"),
+			expectError: true,
+		},
+		{
+			name:        "truncated header",
+			wav:         []byte("RIFF"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples, rate, err := ReadWAV(bytes.NewReader(tt.wav))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ReadWAV() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			if rate != tt.expectedRate {
+				t.Errorf("ReadWAV() rate = %d, want %d", rate, tt.expectedRate)
+			}
+			if len(samples) != len(tt.expected) {
+				t.Fatalf("ReadWAV() returned %d samples, want %d", len(samples), len(tt.expected))
+			}
+			for i := range samples {
+				if math.Abs(float64(samples[i]-tt.expected[i])) > 1e-6 {
+					t.Errorf("Sample %d = %v, want %v", i, samples[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadRaw(t *testing.T) {
+	samples, err := ReadRaw(bytes.NewReader(float32Bytes(0.1, -0.2, 0.3)))
+	if err != nil {
+		t.Fatalf("ReadRaw() error = %v", err)
+	}
+	if len(samples) != 3 || samples[1] != -0.2 {
+		t.Errorf("ReadRaw() = %v", samples)
+	}
+
+	if _, err := ReadRaw(bytes.NewReader([]byte{1, 2, 3})); err == nil {
+		t.Error("Expected error for truncated raw audio")
+	}
+}
+
+func TestWriteWAV(t *testing.T) {
+	input := []float32{0, 0.25, -0.5, 1}
+
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, input, 16000); err != nil {
+		t.Fatalf("WriteWAV() error = %v", err)
+	}
+	if buf.Len() != 44+len(input)*4 {
+		t.Errorf("WriteWAV() wrote %d bytes, want %d", buf.Len(), 44+len(input)*4)
+	}
+
+	samples, rate, err := ReadWAV(&buf)
+	if err != nil {
+		t.Fatalf("ReadWAV() error = %v", err)
+	}
+	if rate != 16000 {
+		t.Errorf("Sample rate = %d, want 16000", rate)
+	}
+	if len(samples) != len(input) {
+		t.Fatalf("Got %d samples, want %d", len(samples), len(input))
+	}
+	for i := range input {
+		if samples[i] != input[i] {
+			t.Errorf("Sample %d = %v, want %v", i, samples[i], input[i])
+		}
+	}
+}
+
+func TestDecodeFile(t *testing.T) {
+	samples, rate, err := DecodeFile("../../../testdata/audio/silence_1s.raw")
+	if err != nil {
+		t.Fatalf("DecodeFile() error = %v", err)
+	}
+	if rate != RawSampleRate || len(samples) != 16000 {
+		t.Errorf("DecodeFile() = %d samples at %d Hz, want 16000 at %d Hz", len(samples), rate, RawSampleRate)
+	}
+
+	dir := t.TempDir()
+	wavPath := filepath.Join(dir, "clip.WAV")
+	if err := os.WriteFile(wavPath, buildWAV(wavFormatPCM, 1, 16000, 16, int16Bytes(1, 2), false), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if samples, _, err := DecodeFile(wavPath); err != nil || len(samples) != 2 {
+		t.Errorf("DecodeFile(wav) = %d samples, err %v", len(samples), err)
+	}
+
+	if _, _, err := DecodeFile(filepath.Join(dir, "notes.txt")); err == nil {
+		t.Error("Expected error for unsupported file")
+	}
+}
+
+func TestIsSupportedFile(t *testing.T) {
+	cases := map[string]bool{
+		"a.wav":        true,
+		"b.WAV":        true,
+		"c.raw":        true,
+		"d.mp3":        false,
+		"e.wav.txt":    false,
+		"no-extension": false,
+	}
+	for path, expected := range cases {
+		if IsSupportedFile(path) != expected {
+			t.Errorf("IsSupportedFile(%q) = %v, want %v", path, !expected, expected)
+		}
+	}
+}
+
+func TestReadSamples(t *testing.T) {
+	samples, err := ReadSamples(bytes.NewReader(buildWAV(wavFormatPCM, 1, 16000, 16, int16Bytes(16384, -16384), false)))
+	if err != nil {
+		t.Fatalf("ReadSamples() error = %v", err)
+	}
+	if len(samples) != 2 || samples[0] != 0.5 || samples[1] != -0.5 {
+		t.Errorf("ReadSamples() = %v", samples)
+	}
+
+	if _, err := ReadSamples(bytes.NewReader(buildWAV(wavFormatPCM, 1, 44100, 16, int16Bytes(0), false))); err == nil {
+		t.Error("Expected error for non-16kHz WAV")
+	}
+
+	samples, err = ReadSamples(bytes.NewReader(float32Bytes(0.25, -0.75)))
+	if err != nil || len(samples) != 2 || samples[1] != -0.75 {
+		t.Errorf("ReadSamples(raw) = %v, %v", samples, err)
+	}
+
+	samples, err = ReadSamples(bytes.NewReader(nil))
+	if err != nil || len(samples) != 0 {
+		t.Errorf("ReadSamples(empty) = %v, %v; want no samples", samples, err)
+	}
+}
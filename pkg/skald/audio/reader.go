@@ -0,0 +1,105 @@
+package audio
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+)
+
+// Sample formats accepted by ReaderCapture, named as in ffmpeg and sox
+const (
+	FormatF32LE = "f32le" // 32-bit float little-endian
+	FormatS16LE = "s16le" // 16-bit signed integer little-endian
+)
+
+// readerFrameMillis is the duration of each frame ReaderCapture delivers
+const readerFrameMillis = 100
+
+// ReaderCapture implements audio capture over a stream of raw mono samples,
+// such as audio piped in from ffmpeg or sox. The channel is closed when the
+// stream ends, which finishes the session.
+type ReaderCapture struct {
+	reader         io.Reader
+	bytesPerSample int
+	decode         func([]byte) float32
+	frameSamples   int
+}
+
+// NewReaderCapture creates a capture reading samples at sampleRate in the
+// given format (FormatF32LE or FormatS16LE) from r
+func NewReaderCapture(r io.Reader, format string, sampleRate uint32) (*ReaderCapture, error) {
+	capture := &ReaderCapture{
+		reader:       r,
+		frameSamples: max(int(sampleRate)*readerFrameMillis/1000, 1),
+	}
+	switch format {
+	case FormatF32LE:
+		capture.bytesPerSample = 4
+		capture.decode = decodeF32LE
+	case FormatS16LE:
+		capture.bytesPerSample = 2
+		capture.decode = decodeS16LE
+	default:
+		return nil, fmt.Errorf("unsupported input format: %q (valid: %s, %s)", format, FormatF32LE, FormatS16LE)
+	}
+	return capture, nil
+}
+
+// decodeF32LE converts a 32-bit float little-endian sample
+func decodeF32LE(b []byte) float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(b))
+}
+
+// decodeS16LE converts a 16-bit signed little-endian sample to the -1..1 range
+func decodeS16LE(b []byte) float32 {
+	return float32(int16(binary.LittleEndian.Uint16(b))) / 32768.0 //nolint:gosec
+}
+
+// Start begins reading the stream. Unlike a live device, frames are never
+// dropped: reading waits while the consumer is busy.
+func (r *ReaderCapture) Start(ctx context.Context) (<-chan []float32, error) {
+	audioChan := make(chan []float32, DefaultBufferSize)
+
+	go func() {
+		defer close(audioChan)
+
+		buf := make([]byte, r.frameSamples*r.bytesPerSample)
+		for {
+			n, err := io.ReadFull(r.reader, buf)
+			if count := n / r.bytesPerSample; count > 0 {
+				samples := make([]float32, count)
+				for i := range samples {
+					samples[i] = r.decode(buf[i*r.bytesPerSample:])
+				}
+				select {
+				case audioChan <- samples:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+					log.Printf("Warning: Failed to read audio input: %v", err)
+				}
+				return
+			}
+		}
+	}()
+
+	return audioChan, nil
+}
+
+// Live reports false: the stream is read as fast as it is consumed, so
+// there is never a real-time backlog to drop
+func (r *ReaderCapture) Live() bool {
+	return false
+}
+
+// Stop is a no-op; closing the underlying reader is up to the caller
+func (r *ReaderCapture) Stop() error {
+	return nil
+}
@@ -0,0 +1,71 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestReaderCapture(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   []byte
+		want   []float32
+	}{
+		{"f32le", FormatF32LE, float32Bytes(0.5, -0.25, 1), []float32{0.5, -0.25, 1}},
+		{"s16le", FormatS16LE, int16Bytes(16384, -32768), []float32{0.5, -1}},
+		{"trailing partial sample dropped", FormatS16LE, append(int16Bytes(16384), 0x01), []float32{0.5}},
+		{"empty input", FormatF32LE, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 40Hz gives 4-sample frames, so longer inputs span several frames
+			capture, err := NewReaderCapture(bytes.NewReader(tt.data), tt.format, 40)
+			if err != nil {
+				t.Fatalf("NewReaderCapture() error = %v", err)
+			}
+			audioChan, err := capture.Start(context.Background())
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			var got []float32
+			for frame := range audioChan {
+				got = append(got, frame...)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Got samples %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Sample %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReaderCapture_Frames(t *testing.T) {
+	samples := make([]float32, 10)
+	capture, err := NewReaderCapture(bytes.NewReader(float32Bytes(samples...)), FormatF32LE, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	audioChan, _ := capture.Start(context.Background())
+
+	var sizes []int
+	for frame := range audioChan {
+		sizes = append(sizes, len(frame))
+	}
+	if len(sizes) != 3 || sizes[0] != 4 || sizes[1] != 4 || sizes[2] != 2 {
+		t.Errorf("Frame sizes = %v, want [4 4 2]", sizes)
+	}
+}
+
+func TestNewReaderCapture_InvalidFormat(t *testing.T) {
+	if _, err := NewReaderCapture(bytes.NewReader(nil), "u8", 16000); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
@@ -2,6 +2,30 @@ package audio
 
 import "math"
 
+// Voice activity detection works on 30ms frames. A frame is voiced when it
+// is louder than the silence threshold and crosses zero less often than
+// broadband noise such as fan hiss, which crosses on about half its samples.
+const (
+	vadFrameMillis         = 30
+	vadMaxZeroCrossingRate = 0.35
+	vadMinVoicedFrames     = 3 // About 90ms of voiced audio
+)
+
+// Silence trimming measures loudness over 10ms frames and keeps 100ms of
+// padding around the loud region so word onsets and tails are not clipped
+const (
+	trimFrameMillis   = 10
+	trimPaddingMillis = 100
+)
+
+// Calibration sets the silence threshold a margin above the measured
+// background noise, never below a floor so a muted or digitally silent
+// input still yields a usable threshold
+const (
+	DefaultCalibrationMargin = 2.0
+	minCalibratedThreshold   = 0.001
+)
+
 // SilenceDetector implements silence detection
 type SilenceDetector struct{}
 
@@ -28,6 +52,41 @@ func (s *SilenceDetector) IsSilent(samples []float32, threshold float32) bool {
 
 // CalculateRMS calculates the root mean square of samples
 func (s *SilenceDetector) CalculateRMS(samples []float32) float32 {
+	return rms(samples)
+}
+
+// SuggestThreshold returns a silence threshold for a recording of background
+// noise: its RMS times margin, so the room reads as silence but speech,
+// which is louder, does not
+func (s *SilenceDetector) SuggestThreshold(noise []float32, margin float32) float32 {
+	threshold := s.CalculateRMS(noise) * margin
+	if threshold < minCalibratedThreshold {
+		return minCalibratedThreshold
+	}
+	return threshold
+}
+
+// ClipLevel is the magnitude at which a sample counts as clipped, just
+// below full scale since converters rarely hit exactly ±1.0
+const ClipLevel = 0.99
+
+// ClippedFraction returns the fraction of samples at or beyond ClipLevel,
+// a sign that the input gain is too high
+func ClippedFraction(samples []float32) float32 {
+	if len(samples) == 0 {
+		return 0
+	}
+	clipped := 0
+	for _, sample := range samples {
+		if sample >= ClipLevel || sample <= -ClipLevel {
+			clipped++
+		}
+	}
+	return float32(clipped) / float32(len(samples))
+}
+
+// rms calculates the root mean square of samples
+func rms(samples []float32) float32 {
 	if len(samples) == 0 {
 		return 0
 	}
@@ -37,4 +96,70 @@ func (s *SilenceDetector) CalculateRMS(samples []float32) float32 {
 		sum += float64(sample * sample)
 	}
 	return float32(math.Sqrt(sum / float64(len(samples))))
-}
\ No newline at end of file
+}
+
+// HasSpeech reports whether samples contain voice activity, so buffers of
+// pure background noise can skip transcription entirely
+func (s *SilenceDetector) HasSpeech(samples []float32, sampleRate uint32, threshold float32) bool {
+	frameSize := int(sampleRate) * vadFrameMillis / 1000
+	if frameSize < 2 {
+		return !s.IsSilent(samples, threshold)
+	}
+
+	voiced := 0
+	for start := 0; start+frameSize <= len(samples); start += frameSize {
+		frame := samples[start : start+frameSize]
+		if s.CalculateRMS(frame) < threshold {
+			continue
+		}
+		if zeroCrossingRate(frame) > vadMaxZeroCrossingRate {
+			continue
+		}
+		voiced++
+		if voiced >= vadMinVoicedFrames {
+			return true
+		}
+	}
+	return false
+}
+
+// zeroCrossingRate returns the fraction of adjacent samples that change sign
+func zeroCrossingRate(samples []float32) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	crossings := 0
+	for i := 1; i < len(samples); i++ {
+		if (samples[i-1] >= 0) != (samples[i] >= 0) {
+			crossings++
+		}
+	}
+	return float64(crossings) / float64(len(samples)-1)
+}
+
+// TrimSilence returns the part of samples between the first and last frame
+// louder than threshold, plus a little padding. A buffer with no loud frame
+// trims to empty. The result shares memory with samples.
+func (s *SilenceDetector) TrimSilence(samples []float32, sampleRate uint32, threshold float32) []float32 {
+	frameSize := int(sampleRate) * trimFrameMillis / 1000
+	if frameSize < 1 {
+		frameSize = 1
+	}
+
+	first, last := -1, -1
+	for start := 0; start < len(samples); start += frameSize {
+		end := min(start+frameSize, len(samples))
+		if s.CalculateRMS(samples[start:end]) >= threshold {
+			if first < 0 {
+				first = start
+			}
+			last = end
+		}
+	}
+	if first < 0 {
+		return samples[:0]
+	}
+
+	padding := int(sampleRate) * trimPaddingMillis / 1000
+	return samples[max(first-padding, 0):min(last+padding, len(samples))]
+}
@@ -2,6 +2,7 @@ package audio
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -167,4 +168,147 @@ func BenchmarkSilenceDetector_CalculateRMS(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		detector.CalculateRMS(samples)
 	}
-}
\ No newline at end of file
+}
+
+func TestSilenceDetector_SuggestThreshold(t *testing.T) {
+	detector := NewSilenceDetector()
+
+	tests := []struct {
+		name   string
+		noise  []float32
+		margin float32
+		want   float32
+	}{
+		{"constant noise", []float32{0.01, -0.01, 0.01, -0.01}, 2, 0.02},
+		{"larger margin", []float32{0.01, -0.01}, 3, 0.03},
+		{"digital silence uses floor", make([]float32, 100), 2, minCalibratedThreshold},
+		{"no samples uses floor", nil, 2, minCalibratedThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detector.SuggestThreshold(tt.noise, tt.margin)
+			if math.Abs(float64(got-tt.want)) > 1e-6 {
+				t.Errorf("SuggestThreshold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClippedFraction(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []float32
+		want    float32
+	}{
+		{"empty", nil, 0},
+		{"quiet", []float32{0.1, -0.2, 0.5, -0.5}, 0},
+		{"full scale both signs", []float32{1, -1, 0.5, 0}, 0.5},
+		{"near full scale", []float32{0.995, 0.2, 0.2, 0.2}, 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClippedFraction(tt.samples); got != tt.want {
+				t.Errorf("ClippedFraction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSilenceDetector_HasSpeech(t *testing.T) {
+	detector := NewSilenceDetector()
+	const rate = 16000
+
+	tone := make([]float32, rate/2)
+	for i := range tone {
+		tone[i] = float32(0.3 * math.Sin(2*math.Pi*200*float64(i)/rate))
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	noise := make([]float32, rate/2)
+	for i := range noise {
+		noise[i] = float32(rng.Float64()*0.6 - 0.3)
+	}
+
+	blip := make([]float32, rate/2)
+	copy(blip, tone[:rate*30/1000]) // A single voiced frame
+
+	tests := []struct {
+		name    string
+		samples []float32
+		want    bool
+	}{
+		{"voiced tone", tone, true},
+		{"broadband noise", noise, false},
+		{"silence", make([]float32, rate/2), false},
+		{"single short blip", blip, false},
+		{"empty", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detector.HasSpeech(tt.samples, rate, 0.01); got != tt.want {
+				t.Errorf("HasSpeech() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZeroCrossingRate(t *testing.T) {
+	tests := []struct {
+		samples []float32
+		want    float64
+	}{
+		{[]float32{1, -1, 1, -1, 1}, 1},
+		{[]float32{1, 1, 1, 1}, 0},
+		{[]float32{1, -1, -1, -1, 1}, 0.5},
+		{[]float32{1}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := zeroCrossingRate(tt.samples); got != tt.want {
+			t.Errorf("zeroCrossingRate(%v) = %v, want %v", tt.samples, got, tt.want)
+		}
+	}
+}
+
+func TestSilenceDetector_TrimSilence(t *testing.T) {
+	detector := NewSilenceDetector()
+	const rate = 16000
+	padding := rate * trimPaddingMillis / 1000
+
+	speech := make([]float32, rate/2)
+	for i := range speech {
+		speech[i] = float32(0.3 * math.Sin(2*math.Pi*200*float64(i)/rate))
+	}
+	quiet := func(n int) []float32 {
+		samples := make([]float32, n)
+		for i := range samples {
+			samples[i] = 0.001
+		}
+		return samples
+	}
+
+	tests := []struct {
+		name    string
+		samples []float32
+		want    int
+	}{
+		{"silence on both sides", append(append(quiet(rate), speech...), quiet(rate)...), len(speech) + 2*padding},
+		{"trailing silence only", append(append([]float32{}, speech...), quiet(rate)...), len(speech) + padding},
+		{"short padding kept whole", append(append(quiet(padding/2), speech...), quiet(padding/2)...), len(speech) + padding},
+		{"no silence", speech, len(speech)},
+		{"all silence", quiet(rate), 0},
+		{"empty", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detector.TrimSilence(tt.samples, rate, 0.01)
+			if len(got) != tt.want {
+				t.Errorf("TrimSilence() kept %d samples, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
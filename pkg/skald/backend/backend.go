@@ -0,0 +1,101 @@
+// Package backend lets transcription engines be chosen by name
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"skald/pkg/skald"
+	"skald/pkg/skald/transcriber"
+)
+
+// Default is the transcription backend used unless another is chosen:
+// whisper.cpp running locally
+const Default = "whisper"
+
+// HTTP uploads audio to a remote transcription service, see
+// transcriber.HTTPTranscriber
+const HTTP = "http"
+
+// Config holds the settings a transcription backend is created from.
+// Each backend uses the fields that apply to it.
+type Config struct {
+	Model      string            // Model file for local engines
+	Language   string            // Language code, or "auto" to detect it
+	SampleRate uint32            // Rate of the audio sent to a remote service (0 means skald.SampleRate)
+	URL        string            // Endpoint of a remote service
+	Headers    map[string]string // Sent with each request to a remote service, e.g. Authorization
+	Timeout    time.Duration     // Request timeout for a remote service (0 uses the backend default)
+	TextField  string            // JSON field holding a remote service's transcript, empty for plain text
+}
+
+// Factory creates a transcriber for a backend
+type Factory func(config Config) (skald.Transcriber, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Factory{}
+)
+
+func init() {
+	Register(Default, func(config Config) (skald.Transcriber, error) {
+		whisper, err := transcriber.NewWhisper(config.Model, config.Language)
+		if err != nil {
+			return nil, err
+		}
+		return whisper, nil
+	})
+	Register(HTTP, func(config Config) (skald.Transcriber, error) {
+		remote, err := transcriber.NewHTTPTranscriber(config.URL, config.Language, config.SampleRate)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range config.Headers {
+			remote.SetHeader(name, value)
+		}
+		remote.SetTimeout(config.Timeout)
+		remote.SetTextField(config.TextField)
+		return remote, nil
+	})
+}
+
+// Register makes a transcription backend available to New by name. It is
+// meant to be called from init. Like database/sql.Register, it panics if
+// name is already registered or factory is nil.
+func Register(name string, factory Factory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	if factory == nil {
+		panic("backend: Register factory is nil")
+	}
+	if _, exists := backends[name]; exists {
+		panic("backend: Register called twice for backend " + name)
+	}
+	backends[name] = factory
+}
+
+// Names returns the names of the registered backends, sorted
+func Names() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates a transcriber with the named backend
+func New(backend string, config Config) (skald.Transcriber, error) {
+	backendsMu.RLock()
+	factory, ok := backends[backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown transcription backend %q (available: %s)", backend, strings.Join(Names(), ", "))
+	}
+	return factory(config)
+}
@@ -0,0 +1,169 @@
+package backend
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"skald/pkg/skald"
+	"skald/pkg/skald/mocks"
+	"skald/pkg/skald/transcriber"
+)
+
+// fakeFactory returns models whose contexts emit fixed segments
+type fakeFactory struct {
+	segments  []string
+	fail      bool
+	lastAudio []float32
+	closed    int
+}
+
+func (f *fakeFactory) NewModel(modelPath string) (transcriber.WhisperModel, error) {
+	if f.fail {
+		return nil, errors.New("model not found")
+	}
+	return &fakeModel{factory: f}, nil
+}
+
+type fakeModel struct{ factory *fakeFactory }
+
+func (m *fakeModel) NewContext() (transcriber.WhisperContext, error) {
+	return &fakeContext{factory: m.factory}, nil
+}
+
+func (m *fakeModel) Close() error {
+	m.factory.closed++
+	return nil
+}
+
+type fakeContext struct {
+	factory *fakeFactory
+	next    int
+}
+
+func (c *fakeContext) SetLanguage(lang string) error { return nil }
+
+func (c *fakeContext) Process(audio []float32, cb1, cb2 interface{}) error {
+	c.factory.lastAudio = audio
+	return nil
+}
+
+func (c *fakeContext) NextSegment() (transcriber.WhisperSegment, error) {
+	if c.next >= len(c.factory.segments) {
+		return nil, errors.New("no more segments")
+	}
+	c.next++
+	return fakeSegment(c.factory.segments[c.next-1]), nil
+}
+
+func (c *fakeContext) DetectedLanguage() string { return "en" }
+
+type fakeSegment string
+
+func (s fakeSegment) GetText() string { return string(s) }
+
+// useFakeFactory installs a fake model factory for the duration of a test
+func useFakeFactory(t *testing.T, factory *fakeFactory) {
+	t.Helper()
+	transcriber.SetModelFactory(factory)
+	t.Cleanup(func() {
+		transcriber.SetModelFactory(&transcriber.DefaultWhisperModelFactory{})
+	})
+}
+
+func TestNew_Whisper(t *testing.T) {
+	factory := &fakeFactory{segments: []string{" Hello"}}
+	useFakeFactory(t, factory)
+
+	trans, err := New(Default, Config{Model: "model.bin", Language: "en"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer trans.Close()
+	if _, ok := trans.(*transcriber.Whisper); !ok {
+		t.Errorf("Expected *transcriber.Whisper, got %T", trans)
+	}
+	if text, err := trans.Transcribe([]float32{0.1}); err != nil || text != "Hello" {
+		t.Errorf("Transcribe() = %q, %v, want %q", text, err, "Hello")
+	}
+}
+
+func TestNew_ModelError(t *testing.T) {
+	useFakeFactory(t, &fakeFactory{fail: true})
+
+	trans, err := New(Default, Config{Model: "missing.bin"})
+	if err == nil {
+		t.Fatal("Expected error when model cannot be loaded")
+	}
+	if trans != nil {
+		t.Errorf("Expected nil transcriber on error, got %T", trans)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	mock := &mocks.MockTranscriber{}
+	var got Config
+	Register("test-registry", func(config Config) (skald.Transcriber, error) {
+		got = config
+		return mock, nil
+	})
+
+	trans, err := New("test-registry", Config{Language: "de"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if trans != mock || got.Language != "de" {
+		t.Errorf("Expected registered factory to be called with the config, got %T and %+v", trans, got)
+	}
+
+	names := strings.Join(Names(), ",")
+	if !strings.Contains(names, "test-registry") || !strings.Contains(names, Default) {
+		t.Errorf("Names() = %q, want test-registry and %s", names, Default)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic when registering a backend twice")
+		}
+	}()
+	Register("test-registry", func(config Config) (skald.Transcriber, error) { return mock, nil })
+}
+
+func TestNew_Unknown(t *testing.T) {
+	_, err := New("carrier-pigeon", Config{})
+	if err == nil || !strings.Contains(err.Error(), "unknown transcription backend") || !strings.Contains(err.Error(), Default) {
+		t.Errorf("Expected error listing available backends, got %v", err)
+	}
+}
+
+func TestNew_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, `{"transcript": "hello"}`)
+	}))
+	defer server.Close()
+
+	trans, err := New(HTTP, Config{
+		URL:       server.URL,
+		Language:  "en",
+		Headers:   map[string]string{"X-Api-Key": "secret"},
+		TextField: "transcript",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer trans.Close()
+	if text, err := trans.Transcribe([]float32{0.1}); err != nil || text != "hello" {
+		t.Errorf("Transcribe() = %q, %v, want %q", text, err, "hello")
+	}
+
+	if _, err := New(HTTP, Config{}); err == nil {
+		t.Error("Expected error without a URL")
+	}
+}
@@ -0,0 +1,209 @@
+package batch
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"skald/pkg/skald"
+	"skald/pkg/skald/audio"
+)
+
+// Result describes the outcome of transcribing a single file
+type Result struct {
+	Path       string
+	OutputPath string
+	Skipped    bool // The transcript already existed and was left alone
+	Err        error
+}
+
+// Summary collects per-file results for a batch run
+type Summary struct {
+	Results   []Result
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
+
+// Options controls a batch run
+type Options struct {
+	Workers   int  // Files transcribed at once, at least 1
+	Overwrite bool // Replace existing transcripts instead of skipping their audio
+
+	// NewTranscriber creates a transcriber for each worker after the first,
+	// which uses the one passed to RunWithOptions. Without it the workers
+	// share that transcriber and take turns, since whisper cannot run two
+	// transcriptions on one model at once.
+	NewTranscriber func() (skald.Transcriber, error)
+}
+
+// FindFiles returns all supported audio files under dir in sorted order
+func FindFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && audio.IsSupportedFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan directory: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// OutputPath returns the transcript path written next to an audio file
+func OutputPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".txt"
+}
+
+// Run transcribes every supported audio file under dir, writing a .txt
+// transcript next to each one. Files whose transcript already exists are
+// skipped, since it may be a hand-written reference such as those used by
+// eval. The transcriber is reused across files so the model is only loaded
+// once. Per-file failures are recorded in the summary rather than aborting
+// the batch.
+func Run(dir string, transcriber skald.Transcriber) (Summary, error) {
+	return RunWithOptions(dir, transcriber, Options{Workers: 1})
+}
+
+// RunWithOptions is Run with up to options.Workers files transcribed at
+// once. Each worker needs its own transcriber from options.NewTranscriber
+// to run in parallel; for Whisper that is a separate copy of the model, so
+// memory grows with every worker. Results stay in file order.
+func RunWithOptions(dir string, transcriber skald.Transcriber, options Options) (Summary, error) {
+	workers := options.Workers
+	if workers < 1 {
+		return Summary{}, fmt.Errorf("invalid worker count: %d (must be at least 1)", workers)
+	}
+	files, err := FindFiles(dir)
+	if err != nil {
+		return Summary{}, err
+	}
+	workers = min(workers, len(files))
+
+	transcribers, err := workerTranscribers(transcriber, workers, options.NewTranscriber)
+	if err != nil {
+		return Summary{}, err
+	}
+	defer func() {
+		for _, extra := range transcribers[1:] {
+			_ = extra.Close()
+		}
+	}()
+
+	results := make([]Result, len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = transcribeFile(files[i], transcribers[w%len(transcribers)], options.Overwrite)
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	summary := Summary{Results: results}
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			summary.Failed++
+		case result.Skipped:
+			summary.Skipped++
+		default:
+			summary.Succeeded++
+		}
+	}
+	return summary, nil
+}
+
+// workerTranscribers returns the transcribers used by workers, starting
+// with shared. Without newTranscriber the workers share one transcriber
+// guarded by a mutex.
+func workerTranscribers(shared skald.Transcriber, workers int, newTranscriber func() (skald.Transcriber, error)) ([]skald.Transcriber, error) {
+	if workers <= 1 {
+		return []skald.Transcriber{shared}, nil
+	}
+	if newTranscriber == nil {
+		return []skald.Transcriber{&lockedTranscriber{Transcriber: shared}}, nil
+	}
+	transcribers := []skald.Transcriber{shared}
+	for len(transcribers) < workers {
+		extra, err := newTranscriber()
+		if err != nil {
+			for _, created := range transcribers[1:] {
+				_ = created.Close()
+			}
+			return nil, fmt.Errorf("failed to create transcriber for worker %d: %w", len(transcribers)+1, err)
+		}
+		transcribers = append(transcribers, extra)
+	}
+	return transcribers, nil
+}
+
+// lockedTranscriber lets workers share a transcriber one call at a time
+type lockedTranscriber struct {
+	skald.Transcriber
+	mu sync.Mutex
+}
+
+func (l *lockedTranscriber) Transcribe(audio []float32) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.Transcriber.Transcribe(audio)
+}
+
+// TranscribeFile decodes a supported audio file and returns its transcript
+func TranscribeFile(path string, transcriber skald.Transcriber) (string, error) {
+	samples, rate, err := audio.DecodeFile(path)
+	if err != nil {
+		return "", err
+	}
+	if rate != skald.SampleRate {
+		return "", fmt.Errorf("unsupported sample rate %d Hz (expected %d Hz)", rate, skald.SampleRate)
+	}
+
+	text, err := transcriber.Transcribe(samples)
+	if err != nil {
+		return "", fmt.Errorf("transcription failed: %w", err)
+	}
+	return text, nil
+}
+
+// transcribeFile transcribes one file and writes the transcript next to it,
+// skipping the file if a transcript exists unless overwrite is set
+func transcribeFile(path string, transcriber skald.Transcriber, overwrite bool) Result {
+	result := Result{Path: path, OutputPath: OutputPath(path)}
+	if !overwrite {
+		if _, err := os.Stat(result.OutputPath); err == nil {
+			result.Skipped = true
+			return result
+		}
+	}
+
+	text, err := TranscribeFile(path, transcriber)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if err := os.WriteFile(result.OutputPath, []byte(text+"\n"), 0600); err != nil {
+		result.Err = fmt.Errorf("failed to write transcript: %w", err)
+	}
+	return result
+}
@@ -0,0 +1,232 @@
+package batch
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"skald/pkg/skald"
+	"skald/pkg/skald/mocks"
+)
+
+// writeRaw writes float32 little-endian samples to path
+func writeRaw(t *testing.T, path string, samples []float32) {
+	t.Helper()
+	data := make([]byte, len(samples)*4)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(s))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeRaw(t, filepath.Join(dir, "b.raw"), []float32{0})
+	writeRaw(t, filepath.Join(dir, "nested", "a.raw"), []float32{0})
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("skip"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := FindFiles(dir)
+	if err != nil {
+		t.Fatalf("FindFiles() error = %v", err)
+	}
+
+	expected := []string{filepath.Join(dir, "b.raw"), filepath.Join(dir, "nested", "a.raw")}
+	if len(files) != len(expected) {
+		t.Fatalf("FindFiles() = %v, want %v", files, expected)
+	}
+	for i := range expected {
+		if files[i] != expected[i] {
+			t.Errorf("FindFiles()[%d] = %s, want %s", i, files[i], expected[i])
+		}
+	}
+
+	if _, err := FindFiles(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected error for missing directory")
+	}
+}
+
+func TestOutputPath(t *testing.T) {
+	if got := OutputPath("/tmp/rec/meeting.wav"); got != "/tmp/rec/meeting.txt" {
+		t.Errorf("OutputPath() = %s", got)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	writeRaw(t, filepath.Join(dir, "one.raw"), []float32{0.1, 0.2})
+	writeRaw(t, filepath.Join(dir, "two.raw"), []float32{0.3})
+	writeRaw(t, filepath.Join(dir, "broken.raw"), []float32{0.4, 0.5, 0.6})
+
+	mockTrans := &mocks.MockTranscriber{
+		TranscribeFunc: func(audio []float32) (string, error) {
+			switch len(audio) {
+			case 2:
+				return "first file", nil
+			case 1:
+				return "second file", nil
+			}
+			return "", errors.New("model failure")
+		},
+	}
+
+	summary, err := Run(dir, mockTrans)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if summary.Succeeded != 2 || summary.Failed != 1 {
+		t.Errorf("Run() succeeded=%d failed=%d, want 2/1", summary.Succeeded, summary.Failed)
+	}
+	if mockTrans.TranscribeCalled != 3 {
+		t.Errorf("Expected one transcription per file, got %d", mockTrans.TranscribeCalled)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "one.txt"))
+	if err != nil || string(data) != "first file\n" {
+		t.Errorf("one.txt = %q, err %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "broken.txt")); !os.IsNotExist(err) {
+		t.Error("No transcript should be written for a failed file")
+	}
+}
+
+func TestRun_RejectsWrongSampleRate(t *testing.T) {
+	dir := t.TempDir()
+
+	// Minimal 8kHz 16-bit PCM WAV with one sample
+	wav := []byte("RIFF\x00\x00\x00\x00WAVEfmt \x10\x00\x00\x00\x01\x00\x01\x00\x40\x1f\x00\x00\x80\x3e\x00\x00\x02\x00\x10\x00data\x02\x00\x00\x00\x00\x00")
+	if err := os.WriteFile(filepath.Join(dir, "phone.wav"), wav, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	mockTrans := &mocks.MockTranscriber{}
+	summary, err := Run(dir, mockTrans)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if summary.Failed != 1 || summary.Results[0].Err == nil {
+		t.Errorf("Expected sample rate mismatch to fail the file, got %+v", summary)
+	}
+	if mockTrans.TranscribeCalled != 0 {
+		t.Error("Transcriber should not be called for unsupported sample rate")
+	}
+}
+
+func TestRunWithOptions_Workers(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.raw", "b.raw", "c.raw", "d.raw"} {
+		writeRaw(t, filepath.Join(dir, name), []float32{0.1})
+	}
+
+	var running, peak atomic.Int32
+	newMock := func() *mocks.MockTranscriber {
+		return &mocks.MockTranscriber{
+			TranscribeFunc: func(audio []float32) (string, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					old := peak.Load()
+					if n <= old || peak.CompareAndSwap(old, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				return "text", nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		factory  bool
+		wantPeak int32
+	}{
+		{"transcriber per worker runs in parallel", true, 2},
+		{"shared transcriber takes turns", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peak.Store(0)
+			var created []*mocks.MockTranscriber
+			options := Options{Workers: 2, Overwrite: true}
+			if tt.factory {
+				options.NewTranscriber = func() (skald.Transcriber, error) {
+					extra := newMock()
+					created = append(created, extra)
+					return extra, nil
+				}
+			}
+
+			summary, err := RunWithOptions(dir, newMock(), options)
+			if err != nil {
+				t.Fatalf("RunWithOptions() error = %v", err)
+			}
+			if summary.Succeeded != 4 || summary.Failed != 0 {
+				t.Errorf("RunWithOptions() succeeded=%d failed=%d, want 4/0", summary.Succeeded, summary.Failed)
+			}
+			if got := peak.Load(); got != tt.wantPeak {
+				t.Errorf("Peak concurrent transcriptions = %d, want %d", got, tt.wantPeak)
+			}
+			for i, name := range []string{"a.raw", "b.raw", "c.raw", "d.raw"} {
+				if want := filepath.Join(dir, name); summary.Results[i].Path != want {
+					t.Errorf("Results[%d].Path = %s, want %s", i, summary.Results[i].Path, want)
+				}
+			}
+			for _, extra := range created {
+				if extra.CloseCalled != 1 {
+					t.Errorf("Worker transcriber closed %d times, want 1", extra.CloseCalled)
+				}
+			}
+		})
+	}
+
+	if _, err := RunWithOptions(dir, newMock(), Options{}); err == nil {
+		t.Error("Expected error for zero workers")
+	}
+}
+
+func TestRun_SkipsExistingTranscripts(t *testing.T) {
+	dir := t.TempDir()
+	writeRaw(t, filepath.Join(dir, "ref.raw"), []float32{0.1})
+	writeRaw(t, filepath.Join(dir, "new.raw"), []float32{0.1})
+	reference := filepath.Join(dir, "ref.txt")
+	if err := os.WriteFile(reference, []byte("hand-written reference\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	mockTrans := &mocks.MockTranscriber{}
+
+	summary, err := Run(dir, mockTrans)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if summary.Succeeded != 1 || summary.Skipped != 1 || summary.Failed != 0 {
+		t.Errorf("Run() succeeded=%d skipped=%d failed=%d, want 1/1/0", summary.Succeeded, summary.Skipped, summary.Failed)
+	}
+	if mockTrans.TranscribeCalled != 1 {
+		t.Errorf("Expected only the new file to be transcribed, got %d calls", mockTrans.TranscribeCalled)
+	}
+	if data, _ := os.ReadFile(reference); string(data) != "hand-written reference\n" {
+		t.Errorf("Existing transcript was changed to %q", data)
+	}
+
+	summary, err = RunWithOptions(dir, mockTrans, Options{Workers: 1, Overwrite: true})
+	if err != nil || summary.Succeeded != 2 {
+		t.Fatalf("RunWithOptions(Overwrite) = %+v, %v; want 2 succeeded", summary, err)
+	}
+	if data, _ := os.ReadFile(reference); string(data) != "mock transcription\n" {
+		t.Errorf("Expected the transcript to be replaced with -overwrite, got %q", data)
+	}
+}
@@ -1,6 +1,12 @@
 package skald
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// SampleRate is the sample rate whisper expects for input audio
+const SampleRate = 16000
 
 // AudioCapture interface for audio input
 type AudioCapture interface {
@@ -8,18 +14,94 @@ type AudioCapture interface {
 	Stop() error
 }
 
+// FrameDropCounter is implemented by audio captures that count frames
+// discarded because the consumer fell behind
+type FrameDropCounter interface {
+	DroppedFrames() uint64
+}
+
+// LiveCapture is implemented by audio captures that can tell whether their
+// audio arrives in real time. Captures without it are treated as live.
+type LiveCapture interface {
+	Live() bool
+}
+
+// LevelMeter is implemented by audio captures that report the current input level
+type LevelMeter interface {
+	Level() float32
+}
+
 // Transcriber interface for speech-to-text
 type Transcriber interface {
 	Transcribe(audio []float32) (string, error)
 	Close() error
 }
 
+// Result holds a transcription together with metadata about how it was produced
+type Result struct {
+	Text       string
+	Language   string        // Detected language when auto-detecting, otherwise the configured one
+	Duration   time.Duration // Wall-clock processing time
+	Segments   int           // Number of whisper segments in the text
+	NonSpeech  int           // Dropped segments that were only annotations such as [BLANK_AUDIO]
+	Confidence float32       // Mean confidence of the segments in the text, 0-1, or 0 if the model does not report it
+}
+
+// DetailedTranscriber is implemented by transcribers that also report
+// metadata such as the detected language
+type DetailedTranscriber interface {
+	TranscribeDetailed(audio []float32) (Result, error)
+}
+
+// CancelableTranscriber is implemented by transcribers that can give up on
+// an in-flight transcription when ctx is cancelled
+type CancelableTranscriber interface {
+	TranscribeContext(ctx context.Context, audio []float32) (Result, error)
+}
+
+// StreamingTranscriber is implemented by transcribers that can report each
+// segment's text as soon as it is decoded
+type StreamingTranscriber interface {
+	TranscribeStream(ctx context.Context, audio []float32, onSegment func(text string)) (Result, error)
+}
+
 // Output interface for text output
 type Output interface {
 	Write(text string) error
 }
 
+// Meta describes how a transcription was produced
+type Meta struct {
+	Language   string        // Detected or configured language, empty if unknown
+	Latency    time.Duration // Wall-clock transcription time
+	Confidence float32       // Mean segment confidence from 0 to 1, 0 if unknown
+}
+
+// MetaOutput is implemented by outputs that can record transcription metadata
+type MetaOutput interface {
+	WriteMeta(text string, meta Meta) error
+}
+
+// PartialOutput is implemented by outputs that can show interim results
+// while the speaker is still talking. The next Write or WriteMeta call
+// carries the final text, which replaces the interim one.
+type PartialOutput interface {
+	WritePartial(text string) error
+}
+
 // SilenceDetector interface for detecting silence in audio
 type SilenceDetector interface {
 	IsSilent(samples []float32, threshold float32) bool
-}
\ No newline at end of file
+}
+
+// SpeechDetector is implemented by silence detectors that can tell voice
+// activity apart from background noise
+type SpeechDetector interface {
+	HasSpeech(samples []float32, sampleRate uint32, threshold float32) bool
+}
+
+// SilenceTrimmer is implemented by silence detectors that can cut quiet
+// audio from either end of a buffer
+type SilenceTrimmer interface {
+	TrimSilence(samples []float32, sampleRate uint32, threshold float32) []float32
+}
@@ -1,24 +1,76 @@
 package output
 
 import (
+	"errors"
 	"fmt"
 	"io"
-	"os/exec"
+	"log"
+	"os"
 	"strings"
+	"sync/atomic"
+	"unicode/utf8"
 )
 
+// clearLine returns the cursor to the start of the line and erases it
+const clearLine = "\r\033[K"
+
+// DefaultMaxLength caps the clipboard contents built up in append mode
+const DefaultMaxLength = 1000000
+
+// lineEnds maps the supported output separator names to the text printed
+// after each transcription
+var lineEnds = map[string]string{
+	"newline": "\n",
+	"space":   " ",
+	"none":    "",
+}
+
+// ParseLineEnd returns the text printed after each transcription for a
+// separator name: newline, space or none
+func ParseLineEnd(name string) (string, error) {
+	lineEnd, ok := lineEnds[name]
+	if !ok {
+		return "", fmt.Errorf("invalid output separator: %q (valid: newline, space, none)", name)
+	}
+	return lineEnd, nil
+}
+
 // ClipboardOutput implements clipboard and stdout output
 type ClipboardOutput struct {
-	writer io.Writer
+	writer       io.Writer
 	useClipboard bool
+	appendMode   bool        // Add to the current clipboard contents instead of replacing them
+	separator    string      // Placed between existing contents and new text in append mode
+	maxLength    int         // Longest clipboard contents append mode builds up
+	lineEnd      string      // Printed after each transcription
+	terminal     bool        // Writer is a terminal, so interim results can be redrawn
+	partialShown atomic.Bool // An interim result is on the current line
 }
 
-// NewClipboardOutput creates a new clipboard output
+// NewClipboardOutput creates a new clipboard output. A nil writer discards
+// the printed text, for clipboard-only output.
 func NewClipboardOutput(writer io.Writer, useClipboard bool) *ClipboardOutput {
+	if writer == nil {
+		writer = io.Discard
+	}
 	return &ClipboardOutput{
 		writer: writer,
 		useClipboard: useClipboard,
+		maxLength: DefaultMaxLength,
+		lineEnd: "\n",
+		terminal: isTerminal(writer),
+	}
+}
+
+// isTerminal reports whether w is a character device such as a terminal,
+// rather than a file or pipe that should not receive escape codes
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
 	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
 
 // Write writes text to output and optionally clipboard
@@ -27,31 +79,158 @@ func (c *ClipboardOutput) Write(text string) error {
 		return nil
 	}
 
+	// Replace any interim result with the final text
+	prefix := ""
+	if c.partialShown.Swap(false) {
+		prefix = clearLine
+	}
+
 	// Write to writer (usually stdout)
-	if _, err := fmt.Fprintln(c.writer, text); err != nil {
+	if err := writeAll(c.writer, prefix+text+c.lineEnd); err != nil {
 		return fmt.Errorf("failed to write to output: %w", err)
 	}
 	
 	// Copy to clipboard if enabled
 	if c.useClipboard {
-		if err := c.copyToClipboard(text); err != nil {
-			// Non-fatal error - we already printed to stdout
-			fmt.Fprintf(c.writer, "Warning: Failed to copy to clipboard: %v\n", err)
+		clip := text
+		if c.appendMode {
+			clip = appendText(c.readClipboard(), text, c.separator, c.maxLength)
+		}
+		if err := c.copyToClipboard(clip); err != nil {
+			// Non-fatal error - we already printed to stdout. Logged
+			// rather than written there, so stdout holds only transcriptions.
+			log.Printf("Warning: Failed to copy to clipboard: %v", err)
 		}
 	}
 	
 	return nil
 }
 
-// copyToClipboard copies text to system clipboard using xclip
+// WritePartial shows an interim result on the current line without copying
+// it to the clipboard. The line is overwritten by the next partial or final
+// result, so partials are only shown when writing to a terminal, where the
+// escape codes are understood, and each transcription ends its line, since
+// clearing the line would also erase earlier results.
+func (c *ClipboardOutput) WritePartial(text string) error {
+	if text == "" || !c.terminal || c.lineEnd != "\n" {
+		return nil
+	}
+	if err := writeAll(c.writer, clearLine+text); err != nil {
+		return fmt.Errorf("failed to write to output: %w", err)
+	}
+	c.partialShown.Store(true)
+	return nil
+}
+
+// writeAll writes all of text, retrying the rest after a short write. A
+// writer that stops accepting data is reported as an error instead of
+// silently truncating the transcription.
+func writeAll(w io.Writer, text string) error {
+	data := []byte(text)
+	total := len(data)
+	for len(data) > 0 {
+		n, err := w.Write(data)
+		n = max(min(n, len(data)), 0)
+		data = data[n:]
+		if len(data) == 0 {
+			return nil
+		}
+		if n == 0 {
+			if err == nil {
+				err = io.ErrShortWrite
+			}
+			return fmt.Errorf("wrote %d of %d bytes: %w", total-len(data), total, err)
+		}
+		if err != nil && !errors.Is(err, io.ErrShortWrite) {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetAppendMode makes each transcription append to the current clipboard
+// contents, joined by separator, so several phrases can be pasted at once
+func (c *ClipboardOutput) SetAppendMode(separator string) {
+	c.appendMode = true
+	c.separator = separator
+}
+
+// SetLineEnd sets the text printed after each transcription, "\n" by
+// default. A space or nothing keeps output on one line, for piping into
+// tools that would submit a chat message on newline.
+func (c *ClipboardOutput) SetLineEnd(lineEnd string) {
+	c.lineEnd = lineEnd
+}
+
+// SetMaxLength sets the longest clipboard contents, in bytes, that append
+// mode builds up before starting over. Values below 1 use DefaultMaxLength.
+func (c *ClipboardOutput) SetMaxLength(maxLength int) {
+	if maxLength < 1 {
+		maxLength = DefaultMaxLength
+	}
+	c.maxLength = maxLength
+}
+
+// appendText joins existing clipboard text and new text. If the result would
+// exceed maxLength the oldest text is dropped to make room, with a warning,
+// though new text longer than maxLength is still kept whole.
+func appendText(existing, text, separator string, maxLength int) string {
+	if existing == "" {
+		return text
+	}
+	combined := existing + separator + text
+	if len(combined) <= maxLength {
+		return combined
+	}
+	log.Printf("Warning: clipboard contents would exceed %d bytes, dropping the oldest text", maxLength)
+	if len(text) >= maxLength {
+		return text
+	}
+	cut := len(combined) - maxLength
+	for cut < len(combined) && !utf8.RuneStart(combined[cut]) {
+		cut++ // Do not split a multi-byte character
+	}
+	return combined[cut:]
+}
+
+// readClipboard returns the current clipboard text, or "" if it is empty or unreadable
+func (c *ClipboardOutput) readClipboard() string {
+	path, err := platformClipboard.lookPath(platformClipboard.pasteName)
+	if err != nil {
+		return ""
+	}
+	out, err := platformClipboard.pasteCmd(path).Output()
+	if err != nil {
+		return "" // xclip, for one, fails when the clipboard holds no text
+	}
+	return string(out)
+}
+
+// ClipboardTool returns the name of the program used to copy to the clipboard
+// on this platform: xclip, pbcopy or powershell
+func ClipboardTool() string {
+	return platformClipboard.copyName
+}
+
+// CheckClipboard reports whether the clipboard programs are available. The
+// error says how to install them on this platform.
+func CheckClipboard() error {
+	if _, err := platformClipboard.lookPath(platformClipboard.copyName); err != nil {
+		return err
+	}
+	_, err := platformClipboard.lookPath(platformClipboard.pasteName)
+	return err
+}
+
+// copyToClipboard copies text to the system clipboard
 func (c *ClipboardOutput) copyToClipboard(text string) error {
-	// Validate xclip binary exists and get absolute path
-	xclipPath, err := exec.LookPath("xclip")
+	// Validate the binary exists and get its absolute path
+	path, err := platformClipboard.lookPath(platformClipboard.copyName)
 	if err != nil {
-		return fmt.Errorf("xclip not found in PATH: %w", err)
+		return err
 	}
-	
-	cmd := exec.Command(xclipPath, "-selection", "clipboard")
+
+	cmd := platformClipboard.copyCmd(path)
 	cmd.Stdin = strings.NewReader(text)
 	return cmd.Run()
-}
\ No newline at end of file
+}
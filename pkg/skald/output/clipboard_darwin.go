@@ -0,0 +1,6 @@
+//go:build darwin
+
+package output
+
+// platformClipboard is the clipboard access used on macOS
+var platformClipboard = pbcopyCommand
@@ -0,0 +1,6 @@
+//go:build !darwin && !windows
+
+package output
+
+// platformClipboard is the clipboard access used on Linux and other X11 systems
+var platformClipboard = xclipCommand
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// clipboardCommand describes the external programs used to access the
+// clipboard on one platform. The build-tagged clipboard_<os>.go files pick
+// one as platformClipboard; all are defined here so each can be tested on
+// any platform.
+type clipboardCommand struct {
+	copyName  string   // Program that replaces the clipboard with its stdin
+	copyArgs  []string // Arguments for copyName
+	pasteName string   // Program that prints the clipboard to stdout
+	pasteArgs []string // Arguments for pasteName
+	install   string   // How to get the programs if they are missing
+}
+
+// xclipCommand uses xclip on Linux and other X11 systems
+var xclipCommand = clipboardCommand{
+	copyName:  "xclip",
+	copyArgs:  []string{"-selection", "clipboard"},
+	pasteName: "xclip",
+	pasteArgs: []string{"-selection", "clipboard", "-o"},
+	install:   "install xclip, e.g. sudo apt-get install xclip",
+}
+
+// pbcopyCommand uses the pbcopy and pbpaste tools that ship with macOS
+var pbcopyCommand = clipboardCommand{
+	copyName:  "pbcopy",
+	pasteName: "pbpaste",
+	install:   "pbcopy ships with macOS, check that /usr/bin is in PATH",
+}
+
+// powershellCommand uses PowerShell's clipboard cmdlets on Windows, which
+// unlike clip.exe keep non-ASCII text intact
+var powershellCommand = clipboardCommand{
+	copyName:  "powershell",
+	copyArgs:  []string{"-NoProfile", "-NonInteractive", "-Command", "[Console]::In.ReadToEnd() | Set-Clipboard"},
+	pasteName: "powershell",
+	pasteArgs: []string{"-NoProfile", "-NonInteractive", "-Command", "Get-Clipboard -Raw"},
+	install:   "Windows PowerShell is required, check that it is in PATH",
+}
+
+// lookPath returns the absolute path of one of the clipboard programs
+func (c clipboardCommand) lookPath(name string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("%s not found in PATH (%s): %w", name, c.install, err)
+	}
+	return path, nil
+}
+
+// copyCmd builds the command that replaces the clipboard, given the path of copyName
+func (c clipboardCommand) copyCmd(path string) *exec.Cmd {
+	return exec.Command(path, c.copyArgs...)
+}
+
+// pasteCmd builds the command that prints the clipboard, given the path of pasteName
+func (c clipboardCommand) pasteCmd(path string) *exec.Cmd {
+	return exec.Command(path, c.pasteArgs...)
+}
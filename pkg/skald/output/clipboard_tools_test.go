@@ -0,0 +1,63 @@
+package output
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClipboardCommand_Construction(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   clipboardCommand
+		wantCopy  []string
+		wantPaste []string
+	}{
+		{
+			name:      "linux xclip",
+			command:   xclipCommand,
+			wantCopy:  []string{"/usr/bin/xclip", "-selection", "clipboard"},
+			wantPaste: []string{"/usr/bin/xclip", "-selection", "clipboard", "-o"},
+		},
+		{
+			name:      "macOS pbcopy",
+			command:   pbcopyCommand,
+			wantCopy:  []string{"/usr/bin/pbcopy"},
+			wantPaste: []string{"/usr/bin/pbpaste"},
+		},
+		{
+			name:      "windows powershell",
+			command:   powershellCommand,
+			wantCopy:  []string{`C:\powershell.exe`, "-NoProfile", "-NonInteractive", "-Command", "[Console]::In.ReadToEnd() | Set-Clipboard"},
+			wantPaste: []string{`C:\powershell.exe`, "-NoProfile", "-NonInteractive", "-Command", "Get-Clipboard -Raw"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.command.copyCmd(tt.wantCopy[0]).Args; !reflect.DeepEqual(got, tt.wantCopy) {
+				t.Errorf("copyCmd() args = %q, want %q", got, tt.wantCopy)
+			}
+			if got := tt.command.pasteCmd(tt.wantPaste[0]).Args; !reflect.DeepEqual(got, tt.wantPaste) {
+				t.Errorf("pasteCmd() args = %q, want %q", got, tt.wantPaste)
+			}
+		})
+	}
+}
+
+func TestClipboardCommand_MissingToolGuidance(t *testing.T) {
+	for _, command := range []clipboardCommand{xclipCommand, pbcopyCommand, powershellCommand} {
+		_, err := command.lookPath("skald-no-such-clipboard-tool")
+		if err == nil {
+			t.Fatal("Expected error for missing program")
+		}
+		if !errors.Is(err, exec.ErrNotFound) {
+			t.Errorf("Expected exec.ErrNotFound to be wrapped, got %v", err)
+		}
+		if !strings.Contains(err.Error(), command.install) {
+			t.Errorf("Expected install guidance %q in %q", command.install, err)
+		}
+	}
+}
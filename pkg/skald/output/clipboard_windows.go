@@ -0,0 +1,6 @@
+//go:build windows
+
+package output
+
+// platformClipboard is the clipboard access used on Windows
+var platformClipboard = powershellCommand
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"skald/pkg/skald"
+)
+
+// JSONRecord is a single newline-delimited JSON transcription record
+type JSONRecord struct {
+	Text       string    `json:"text"`
+	Timestamp  time.Time `json:"timestamp"`
+	Language   string    `json:"language,omitempty"`
+	LatencyMs  int64     `json:"latency_ms,omitempty"`
+	Confidence float32   `json:"confidence,omitempty"` // Mean segment confidence, 0-1
+	Partial    bool      `json:"partial,omitempty"`    // Interim result, replaced by the next final record
+}
+
+// JSONOutput writes one JSON object per transcription
+type JSONOutput struct {
+	mu     sync.Mutex
+	writer io.Writer
+	now    func() time.Time
+}
+
+// NewJSONOutput creates a new newline-delimited JSON output
+func NewJSONOutput(writer io.Writer) *JSONOutput {
+	return &JSONOutput{
+		writer: writer,
+		now:    time.Now,
+	}
+}
+
+// Write writes text as a JSON record without metadata
+func (j *JSONOutput) Write(text string) error {
+	return j.WriteMeta(text, skald.Meta{})
+}
+
+// WriteMeta writes text as a JSON record including available metadata
+func (j *JSONOutput) WriteMeta(text string, meta skald.Meta) error {
+	if text == "" {
+		return nil
+	}
+
+	return j.writeRecord(JSONRecord{
+		Text:       text,
+		Timestamp:  j.now().UTC(),
+		Language:   meta.Language,
+		LatencyMs:  meta.Latency.Milliseconds(),
+		Confidence: meta.Confidence,
+	})
+}
+
+// WritePartial writes an interim record marked "partial": true
+func (j *JSONOutput) WritePartial(text string) error {
+	if text == "" {
+		return nil
+	}
+	return j.writeRecord(JSONRecord{
+		Text:      text,
+		Timestamp: j.now().UTC(),
+		Partial:   true,
+	})
+}
+
+// writeRecord encodes record as one line of JSON
+func (j *JSONOutput) writeRecord(record JSONRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.writer.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write to output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"skald/pkg/skald"
+)
+
+func TestJSONOutput_Write(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewJSONOutput(&buf)
+	out.now = func() time.Time { return time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC) }
+
+	if err := out.Write(`She said "hi" & left <now>`); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := out.WriteMeta("Hola\nmundo", skald.Meta{Language: "es", Latency: 250 * time.Millisecond, Confidence: 0.75}); err != nil {
+		t.Fatalf("WriteMeta() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var first JSONRecord
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if first.Text != `She said "hi" & left <now>` {
+		t.Errorf("Text not round-tripped: %q", first.Text)
+	}
+	if strings.Contains(lines[0], "language") || strings.Contains(lines[0], "latency_ms") || strings.Contains(lines[0], "confidence") {
+		t.Errorf("Missing metadata should be omitted: %s", lines[0])
+	}
+	if !strings.Contains(lines[0], `"timestamp":"2025-03-04T05:06:07Z"`) {
+		t.Errorf("Unexpected timestamp: %s", lines[0])
+	}
+
+	var second JSONRecord
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if second.Text != "Hola\nmundo" || second.Language != "es" || second.LatencyMs != 250 || second.Confidence != 0.75 {
+		t.Errorf("Unexpected record: %+v", second)
+	}
+}
+
+func TestJSONOutput_SkipsEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewJSONOutput(&buf)
+
+	if err := out.Write(""); err != nil {
+		t.Errorf("Write(\"\") error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for empty text, got %q", buf.String())
+	}
+}
+
+func TestJSONOutput_WriterError(t *testing.T) {
+	out := NewJSONOutput(&ErrorWriter{})
+
+	err := out.Write("text")
+	if err == nil || !strings.Contains(err.Error(), "failed to write to output") {
+		t.Errorf("Expected wrapped writer error, got %v", err)
+	}
+}
+
+func TestJSONOutput_WritePartial(t *testing.T) {
+	var buf bytes.Buffer
+	out := NewJSONOutput(&buf)
+
+	if err := out.WritePartial("Hello wor"); err != nil {
+		t.Fatalf("WritePartial() error = %v", err)
+	}
+	if err := out.Write("Hello world"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var partial, final JSONRecord
+	if err := json.Unmarshal([]byte(lines[0]), &partial); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &final); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if !partial.Partial || partial.Text != "Hello wor" {
+		t.Errorf("Unexpected partial record: %+v", partial)
+	}
+	if final.Partial || strings.Contains(lines[1], "partial") {
+		t.Errorf("Final record should omit partial: %s", lines[1])
+	}
+}
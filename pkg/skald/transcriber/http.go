@@ -0,0 +1,173 @@
+package transcriber
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"skald/pkg/skald"
+	"skald/pkg/skald/audio"
+)
+
+// DefaultHTTPTimeout limits how long HTTPTranscriber waits for a response
+const DefaultHTTPTimeout = 60 * time.Second
+
+// maxHTTPResponse caps the response body read from the service
+const maxHTTPResponse = 1 << 20
+
+// HTTPTranscriber sends audio to a remote transcription service, such as a
+// whisper.cpp server on a machine with a GPU. Each buffer is uploaded as a
+// WAV file in a multipart/form-data POST with the fields "file" and, unless
+// auto-detecting, "language".
+type HTTPTranscriber struct {
+	url        string
+	language   string
+	sampleRate uint32 // Rate of the uploaded audio, written to the WAV header
+	client     *http.Client
+	header     http.Header
+	textField  string // JSON field holding the transcript, empty for a plain text response
+}
+
+// NewHTTPTranscriber creates a transcriber posting to an http or https URL
+// audio recorded at sampleRate, or skald.SampleRate if it is 0. Responses
+// are read as JSON with the transcript in the "text" field.
+func NewHTTPTranscriber(endpoint, language string, sampleRate uint32) (*HTTPTranscriber, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid transcription service URL %q (expected http:// or https://)", endpoint)
+	}
+	if sampleRate == 0 {
+		sampleRate = skald.SampleRate
+	}
+	return &HTTPTranscriber{
+		url:        endpoint,
+		language:   language,
+		sampleRate: sampleRate,
+		client:     &http.Client{Timeout: DefaultHTTPTimeout},
+		header:     http.Header{},
+		textField:  "text",
+	}, nil
+}
+
+// SetHeader adds a header sent with every request, e.g. Authorization
+func (h *HTTPTranscriber) SetHeader(name, value string) {
+	h.header.Add(name, value)
+}
+
+// SetTimeout limits how long a request may take. Values below 1 use DefaultHTTPTimeout.
+func (h *HTTPTranscriber) SetTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	h.client.Timeout = timeout
+}
+
+// SetTextField sets the JSON field holding the transcript. An empty field
+// reads the whole response body as plain text.
+func (h *HTTPTranscriber) SetTextField(field string) {
+	h.textField = field
+}
+
+// Transcribe converts audio to text
+func (h *HTTPTranscriber) Transcribe(samples []float32) (string, error) {
+	result, err := h.TranscribeContext(context.Background(), samples)
+	return result.Text, err
+}
+
+// TranscribeContext uploads audio and returns the service's transcript. The
+// request is abandoned when ctx is cancelled.
+func (h *HTTPTranscriber) TranscribeContext(ctx context.Context, samples []float32) (Result, error) {
+	start := time.Now()
+	body, contentType, err := h.encodeRequest(samples)
+	if err != nil {
+		return Result{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, body)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create transcription request: %w", err)
+	}
+	for name, values := range h.header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("transcription request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponse))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read transcription response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Result{}, fmt.Errorf("transcription service returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	text, err := h.parseResponse(data)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Text:     text,
+		Language: h.language,
+		Duration: time.Since(start),
+	}, nil
+}
+
+// encodeRequest builds the multipart body holding the audio as a WAV file
+func (h *HTTPTranscriber) encodeRequest(samples []float32) (io.Reader, string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	file, err := form.CreateFormFile("file", "audio.wav")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode transcription request: %w", err)
+	}
+	if err := audio.WriteWAV(file, samples, h.sampleRate); err != nil {
+		return nil, "", err
+	}
+	if h.language != "" && h.language != "auto" {
+		if err := form.WriteField("language", h.language); err != nil {
+			return nil, "", fmt.Errorf("failed to encode transcription request: %w", err)
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to encode transcription request: %w", err)
+	}
+	return &body, form.FormDataContentType(), nil
+}
+
+// parseResponse extracts the transcript from a response body
+func (h *HTTPTranscriber) parseResponse(data []byte) (string, error) {
+	if h.textField == "" {
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("invalid transcription response: %w", err)
+	}
+	text, ok := fields[h.textField].(string)
+	if !ok {
+		return "", fmt.Errorf("transcription response has no %q text field", h.textField)
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// Close releases resources
+func (h *HTTPTranscriber) Close() error {
+	h.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,183 @@
+package transcriber
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"skald/pkg/skald/audio"
+)
+
+func TestNewHTTPTranscriber_InvalidURL(t *testing.T) {
+	for _, endpoint := range []string{"", "localhost:8080", "ftp://example.com/inference", "http://"} {
+		if _, err := NewHTTPTranscriber(endpoint, "en", 0); err == nil {
+			t.Errorf("NewHTTPTranscriber(%q) expected error", endpoint)
+		}
+	}
+}
+
+func TestHTTPTranscriber_Transcribe(t *testing.T) {
+	var gotSamples []float32
+	var gotRate uint32
+	var gotLanguage, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		gotAuth = r.Header.Get("Authorization")
+		gotLanguage = r.FormValue("language")
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Missing file field: %v", err)
+			return
+		}
+		defer file.Close()
+		gotSamples, gotRate, err = audio.ReadWAV(file)
+		if err != nil {
+			t.Errorf("Uploaded file is not a WAV: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"text": " Hello world. "}`)
+	}))
+	defer server.Close()
+
+	h, err := NewHTTPTranscriber(server.URL, "en", 0)
+	if err != nil {
+		t.Fatalf("NewHTTPTranscriber() error = %v", err)
+	}
+	defer h.Close()
+	h.SetHeader("Authorization", "Bearer secret")
+
+	text, err := h.Transcribe([]float32{0.5, -0.5})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if text != "Hello world." {
+		t.Errorf("Transcribe() = %q, want %q", text, "Hello world.")
+	}
+	if len(gotSamples) != 2 || gotSamples[0] != 0.5 || gotRate != 16000 {
+		t.Errorf("Server received %v at %dHz, want [0.5 -0.5] at 16000Hz", gotSamples, gotRate)
+	}
+	if gotLanguage != "en" {
+		t.Errorf("Language field = %q, want en", gotLanguage)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, "Bearer secret")
+	}
+}
+
+func TestHTTPTranscriber_Responses(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		textField string
+		want      string
+		wantErr   string
+	}{
+		{"custom JSON field", 200, `{"transcript": "hi"}`, "transcript", "hi", ""},
+		{"plain text", 200, "hi there\n", "", "hi there", ""},
+		{"missing field", 200, `{"result": "hi"}`, "text", "", `no "text" text field`},
+		{"invalid JSON", 200, "hi", "text", "", "invalid transcription response"},
+		{"server error", 503, "overloaded", "text", "", "503 Service Unavailable: overloaded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			h, _ := NewHTTPTranscriber(server.URL, "auto", 0)
+			h.SetTextField(tt.textField)
+			text, err := h.Transcribe([]float32{0.1})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Transcribe() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || text != tt.want {
+				t.Errorf("Transcribe() = %q, %v, want %q", text, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPTranscriber_AutoLanguageOmitted(t *testing.T) {
+	var hasLanguage bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		_, hasLanguage = r.MultipartForm.Value["language"]
+		io.WriteString(w, `{"text": "hi"}`)
+	}))
+	defer server.Close()
+
+	h, _ := NewHTTPTranscriber(server.URL, "auto", 0)
+	if _, err := h.Transcribe([]float32{0.1}); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if hasLanguage {
+		t.Error("Expected no language field when auto-detecting")
+	}
+}
+
+func TestHTTPTranscriber_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	h, _ := NewHTTPTranscriber(server.URL, "en", 0)
+	h.SetTimeout(50 * time.Millisecond)
+	if _, err := h.Transcribe([]float32{0.1}); err == nil {
+		t.Error("Expected error when the service does not respond in time")
+	}
+}
+
+func TestHTTPTranscriber_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	h, _ := NewHTTPTranscriber(server.URL, "en", 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := h.TranscribeContext(ctx, []float32{0.1}); err == nil {
+		t.Error("Expected error when ctx is cancelled")
+	}
+}
+
+func TestHTTPTranscriber_SampleRate(t *testing.T) {
+	var gotRate uint32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Missing file field: %v", err)
+			return
+		}
+		defer file.Close()
+		_, gotRate, _ = audio.ReadWAV(file)
+		io.WriteString(w, `{"text": "ok"}`)
+	}))
+	defer server.Close()
+
+	h, _ := NewHTTPTranscriber(server.URL, "en", 44100)
+	if _, err := h.Transcribe([]float32{0.1}); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if gotRate != 44100 {
+		t.Errorf("Uploaded WAV rate = %d, want 44100", gotRate)
+	}
+}
@@ -7,11 +7,17 @@ type WhisperModel interface {
 	Close() error
 }
 
+// LanguageLister is implemented by models that report the language codes they support
+type LanguageLister interface {
+	Languages() []string
+}
+
 // WhisperContext defines the interface for whisper context operations
 type WhisperContext interface {
 	SetLanguage(lang string) error
-	Process(audio []float32, cb1, cb2 interface{}) error
+	Process(audio []float32, cb1, cb2 interface{}) error // cb1: encoder begin func() bool, cb2: new segment func(WhisperSegment)
 	NextSegment() (WhisperSegment, error)
+	DetectedLanguage() string
 }
 
 // WhisperSegment represents a transcribed text segment
@@ -19,6 +25,12 @@ type WhisperSegment interface {
 	GetText() string
 }
 
+// SegmentConfidence is implemented by segments that report how confident
+// whisper was in their text, from 0 to 1
+type SegmentConfidence interface {
+	Confidence() float32
+}
+
 // WhisperModelFactory creates whisper models
 type WhisperModelFactory interface {
 	NewModel(modelPath string) (WhisperModel, error)
@@ -88,6 +88,10 @@ type MockWhisperContext struct {
 	ShouldFailProcess    bool
 	ProcessError         error
 	ProcessedAudio       [][]float32
+	Detected             string
+	Threads              uint
+	Temperature          float32
+	TemperatureInc       float32
 }
 
 func (c *MockWhisperContext) SetLanguage(lang string) error {
@@ -110,6 +114,15 @@ func (c *MockWhisperContext) Process(audio []float32, cb1, cb2 interface{}) erro
 		return errors.New("audio processing failed")
 	}
 	
+	if encoderBegin, ok := cb1.(func() bool); ok && !encoderBegin() {
+		return errors.New("encoder aborted")
+	}
+	if onSegment, ok := cb2.(func(WhisperSegment)); ok {
+		for _, segment := range c.Segments {
+			onSegment(segment)
+		}
+	}
+	
 	// Store processed audio for verification
 	audioCopy := make([]float32, len(audio))
 	copy(audioCopy, audio)
@@ -128,6 +141,22 @@ func (c *MockWhisperContext) NextSegment() (WhisperSegment, error) {
 	return segment, nil
 }
 
+func (c *MockWhisperContext) DetectedLanguage() string {
+	return c.Detected
+}
+
+func (c *MockWhisperContext) SetThreads(threads uint) {
+	c.Threads = threads
+}
+
+func (c *MockWhisperContext) SetTemperature(temperature float32) {
+	c.Temperature = temperature
+}
+
+func (c *MockWhisperContext) SetTemperatureFallback(increment float32) {
+	c.TemperatureInc = increment
+}
+
 // AddSegment adds a mock segment to the context
 func (c *MockWhisperContext) AddSegment(text string) {
 	segment := &MockWhisperSegment{Text: text}
@@ -137,12 +166,17 @@ func (c *MockWhisperContext) AddSegment(text string) {
 // MockWhisperSegment simulates a whisper segment
 type MockWhisperSegment struct {
 	Text string
+	Prob float32 // Reported confidence
 }
 
 func (s *MockWhisperSegment) GetText() string {
 	return s.Text
 }
 
+func (s *MockWhisperSegment) Confidence() float32 {
+	return s.Prob
+}
+
 // TestHelper functions for setting up mocks
 
 // NewMockFactory creates a new mock factory with default settings
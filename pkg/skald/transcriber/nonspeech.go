@@ -0,0 +1,14 @@
+package transcriber
+
+import "regexp"
+
+// nonSpeechPattern matches segments made up only of annotations whisper
+// emits for audio without words, such as [BLANK_AUDIO], [MUSIC],
+// (wind blowing) or *coughs*
+var nonSpeechPattern = regexp.MustCompile(`^\s*(?:(?:\[[^\]]*\]|\([^)]*\)|\*[^*]+\*)\s*)+$`)
+
+// IsNonSpeech reports whether a segment's text is only non-speech
+// annotations. Such segments mean the microphone heard sound but no words.
+func IsNonSpeech(text string) bool {
+	return nonSpeechPattern.MatchString(text)
+}
@@ -0,0 +1,29 @@
+package transcriber
+
+import "testing"
+
+func TestIsNonSpeech(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"[BLANK_AUDIO]", true},
+		{" [MUSIC] ", true},
+		{"(wind blowing)", true},
+		{"*coughs*", true},
+		{"[MUSIC] (applause)", true},
+		{"Hello world", false},
+		{"Hello [MUSIC]", false},
+		{"See the notes (page two) for details", false},
+		{"", false},
+		{"  ", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := IsNonSpeech(tt.text); got != tt.want {
+				t.Errorf("IsNonSpeech(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
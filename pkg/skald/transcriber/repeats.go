@@ -0,0 +1,70 @@
+package transcriber
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxRepeatPhraseWords is the longest phrase, in words, checked for repeats
+const maxRepeatPhraseWords = 10
+
+// CollapseRepeats shortens phrases that whisper repeats back to back, a
+// common hallucination on silence or noisy audio ("thank you thank you
+// thank you ..."). Any phrase of up to ten words that occurs more than
+// maxRepeat times in a row is cut down to maxRepeat occurrences. Words are
+// compared ignoring case and surrounding punctuation. A maxRepeat below 1
+// disables the filter, and text without such repeats is returned unchanged.
+func CollapseRepeats(text string, maxRepeat int) string {
+	if maxRepeat < 1 {
+		return text
+	}
+
+	words := strings.Fields(text)
+	changed := false
+	for n := 1; n <= maxRepeatPhraseWords && n*(maxRepeat+1) <= len(words); n++ {
+		var collapsed bool
+		words, collapsed = collapsePhrases(words, n, maxRepeat)
+		changed = changed || collapsed
+	}
+
+	if !changed {
+		return text
+	}
+	return strings.Join(words, " ")
+}
+
+// collapsePhrases cuts runs of the same n-word phrase down to maxRepeat copies
+func collapsePhrases(words []string, n, maxRepeat int) ([]string, bool) {
+	out := make([]string, 0, len(words))
+	collapsed := false
+	for i := 0; i < len(words); {
+		repeats := 1
+		for i+(repeats+1)*n <= len(words) && samePhrase(words[i:i+n], words[i+repeats*n:i+(repeats+1)*n]) {
+			repeats++
+		}
+		if repeats > maxRepeat {
+			out = append(out, words[i:i+maxRepeat*n]...)
+			i += repeats * n
+			collapsed = true
+		} else {
+			out = append(out, words[i])
+			i++
+		}
+	}
+	return out, collapsed
+}
+
+// samePhrase compares words ignoring case and surrounding punctuation
+func samePhrase(a, b []string) bool {
+	for i := range a {
+		if !strings.EqualFold(trimPunct(a[i]), trimPunct(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// trimPunct strips punctuation from both ends of a word
+func trimPunct(word string) string {
+	return strings.TrimFunc(word, unicode.IsPunct)
+}
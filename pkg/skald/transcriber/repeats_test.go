@@ -0,0 +1,91 @@
+package transcriber
+
+import "testing"
+
+func TestCollapseRepeats(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxRepeat int
+		want      string
+	}{
+		{
+			name:      "looped thank you",
+			text:      "Thank you. Thank you. Thank you. Thank you. Thank you.",
+			maxRepeat: 1,
+			want:      "Thank you.",
+		},
+		{
+			name:      "single word stutter",
+			text:      "I think the the the the answer is yes",
+			maxRepeat: 2,
+			want:      "I think the the answer is yes",
+		},
+		{
+			name:      "long looped sentence",
+			text:      "Please subscribe to my channel. Please subscribe to my channel. Please subscribe to my channel.",
+			maxRepeat: 1,
+			want:      "Please subscribe to my channel.",
+		},
+		{
+			name:      "repeats within limit kept",
+			text:      "very very good",
+			maxRepeat: 2,
+			want:      "very very good",
+		},
+		{
+			name:      "case and punctuation ignored",
+			text:      "okay, Okay OKAY! okay",
+			maxRepeat: 1,
+			want:      "okay,",
+		},
+		{
+			name:      "no repeats keeps original spacing",
+			text:      "Hello  world\nagain",
+			maxRepeat: 1,
+			want:      "Hello  world\nagain",
+		},
+		{
+			name:      "disabled",
+			text:      "you you you",
+			maxRepeat: 0,
+			want:      "you you you",
+		},
+		{
+			name:      "empty",
+			text:      "",
+			maxRepeat: 1,
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CollapseRepeats(tt.text, tt.maxRepeat); got != tt.want {
+				t.Errorf("CollapseRepeats() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhisper_SetMaxRepeat(t *testing.T) {
+	model := NewMockModel()
+	model.NewContextFunc = func() (WhisperContext, error) {
+		ctx := NewMockContext()
+		for i := 0; i < 4; i++ {
+			ctx.AddSegment(" Thank you.")
+		}
+		return ctx, nil
+	}
+
+	w := &Whisper{model: model, language: "en"}
+	w.SetMaxRepeat(1)
+
+	text, err := w.Transcribe([]float32{0.1})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if text != "Thank you." {
+		t.Errorf("Transcribe() = %q, want %q", text, "Thank you.")
+	}
+}
@@ -0,0 +1,33 @@
+package transcriber
+
+import (
+	"io"
+
+	"skald/pkg/skald/audio"
+)
+
+// TranscribeSamples transcribes 16kHz mono float32 samples with the given
+// whisper model. It is meant for one-off use: the model is loaded from disk
+// and released on every call, which can take longer than the transcription
+// itself. Callers transcribing repeatedly should create one Whisper with
+// NewWhisper and call its Transcribe method instead.
+func TranscribeSamples(modelPath, language string, samples []float32) (string, error) {
+	whisper, err := NewWhisper(modelPath, language)
+	if err != nil {
+		return "", err
+	}
+	defer whisper.Close()
+
+	return whisper.Transcribe(samples)
+}
+
+// TranscribeReader transcribes audio read from r, a WAV or raw float32
+// stream as accepted by audio.ReadSamples. Audio must be 16kHz. Like
+// TranscribeSamples it loads the model on every call.
+func TranscribeReader(modelPath, language string, r io.Reader) (string, error) {
+	samples, err := audio.ReadSamples(r)
+	if err != nil {
+		return "", err
+	}
+	return TranscribeSamples(modelPath, language, samples)
+}
@@ -0,0 +1,118 @@
+package transcriber
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+// fakeFactory returns models whose contexts emit fixed segments
+type fakeFactory struct {
+	segments  []string
+	fail      bool
+	lastAudio []float32
+	closed    int
+}
+
+func (f *fakeFactory) NewModel(modelPath string) (WhisperModel, error) {
+	if f.fail {
+		return nil, errors.New("model not found")
+	}
+	return &fakeModel{factory: f}, nil
+}
+
+type fakeModel struct{ factory *fakeFactory }
+
+func (m *fakeModel) NewContext() (WhisperContext, error) {
+	return &fakeContext{factory: m.factory}, nil
+}
+
+func (m *fakeModel) Close() error {
+	m.factory.closed++
+	return nil
+}
+
+type fakeContext struct {
+	factory *fakeFactory
+	next    int
+}
+
+func (c *fakeContext) SetLanguage(lang string) error { return nil }
+
+func (c *fakeContext) Process(audio []float32, cb1, cb2 interface{}) error {
+	c.factory.lastAudio = audio
+	return nil
+}
+
+func (c *fakeContext) NextSegment() (WhisperSegment, error) {
+	if c.next >= len(c.factory.segments) {
+		return nil, errors.New("no more segments")
+	}
+	c.next++
+	return fakeSegment(c.factory.segments[c.next-1]), nil
+}
+
+func (c *fakeContext) DetectedLanguage() string { return "en" }
+
+type fakeSegment string
+
+func (s fakeSegment) GetText() string { return string(s) }
+
+// useFakeFactory installs a fake model factory for the duration of a test
+func useFakeFactory(t *testing.T, factory *fakeFactory) {
+	t.Helper()
+	SetModelFactory(factory)
+	t.Cleanup(func() {
+		SetModelFactory(&DefaultWhisperModelFactory{})
+	})
+}
+
+func rawBytes(samples ...float32) []byte {
+	data := make([]byte, len(samples)*4)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(s))
+	}
+	return data
+}
+
+func TestTranscribeSamples(t *testing.T) {
+	factory := &fakeFactory{segments: []string{" Hello", " world."}}
+	useFakeFactory(t, factory)
+
+	text, err := TranscribeSamples("model.bin", "en", []float32{0.1, 0.2})
+	if err != nil {
+		t.Fatalf("TranscribeSamples() error = %v", err)
+	}
+	if text != "Hello world." {
+		t.Errorf("TranscribeSamples() = %q, want %q", text, "Hello world.")
+	}
+	if factory.closed != 1 {
+		t.Errorf("Expected model to be closed after use, closed %d times", factory.closed)
+	}
+}
+
+func TestTranscribeSamples_ModelError(t *testing.T) {
+	useFakeFactory(t, &fakeFactory{fail: true})
+
+	if _, err := TranscribeSamples("missing.bin", "en", []float32{0.1}); err == nil {
+		t.Error("Expected error when model cannot be loaded")
+	}
+}
+
+func TestTranscribeReader_Raw(t *testing.T) {
+	factory := &fakeFactory{segments: []string{"raw audio"}}
+	useFakeFactory(t, factory)
+
+	text, err := TranscribeReader("model.bin", "auto", bytes.NewReader(rawBytes(0.5, -0.5, 0.25)))
+	if err != nil {
+		t.Fatalf("TranscribeReader() error = %v", err)
+	}
+	if text != "raw audio" {
+		t.Errorf("TranscribeReader() = %q", text)
+	}
+	if len(factory.lastAudio) != 3 || factory.lastAudio[1] != -0.5 {
+		t.Errorf("Unexpected decoded samples: %v", factory.lastAudio)
+	}
+}
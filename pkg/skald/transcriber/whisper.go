@@ -1,16 +1,52 @@
 package transcriber
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"skald/pkg/skald"
 )
 
+// ErrAutoDetectUnsupported is returned by CheckAutoDetect when language
+// detection is requested from an English-only model
+var ErrAutoDetectUnsupported = errors.New("language auto-detection needs a multilingual model, this one is English-only")
+
 // Whisper implements transcription using whisper.cpp
 type Whisper struct {
-	model    WhisperModel
-	language string
+	model            WhisperModel
+	mu               sync.RWMutex // Guards language
+	language         string
+	normalizeSpacing bool    // Trim segments and join them with single spaces
+	maxRepeat        int     // Collapse phrases repeated more often than this (0 disables)
+	minConfidence    float32 // Drop segments less confident than this (0 disables)
+	threads          int     // CPU threads per transcription (0 uses the whisper default)
+	temperature      float32 // Sampling temperature of the first decode (0 is greedy)
+	temperatureInc   float32 // Added to the temperature when a decode fails (0 disables fallback)
+}
+
+// threadSetter is implemented by whisper contexts that accept a thread count
+type threadSetter interface {
+	SetThreads(threads uint)
+}
+
+// temperatureSetter is implemented by whisper contexts that accept sampling
+// temperature settings
+type temperatureSetter interface {
+	SetTemperature(temperature float32)
+	SetTemperatureFallback(increment float32)
 }
 
+// DefaultTemperatureIncrement is whisper's default temperature increase when
+// a decode fails its quality checks
+const DefaultTemperatureIncrement = 0.2
+
 // NewWhisper creates a new whisper transcriber
 func NewWhisper(modelPath, language string) (*Whisper, error) {
 	model, err := whisperFactory.NewModel(modelPath)
@@ -19,11 +55,15 @@ func NewWhisper(modelPath, language string) (*Whisper, error) {
 	}
 
 	return &Whisper{
-		model:    model,
-		language: language,
+		model:          model,
+		language:       language,
+		temperatureInc: DefaultTemperatureIncrement,
 	}, nil
 }
 
+// Result is the transcription with metadata returned by Whisper, see skald.Result
+type Result = skald.Result
+
 // SetModelFactory allows injection of a different model factory for testing
 func SetModelFactory(factory WhisperModelFactory) {
 	whisperFactory = factory
@@ -31,38 +71,289 @@ func SetModelFactory(factory WhisperModelFactory) {
 
 // Transcribe converts audio to text
 func (w *Whisper) Transcribe(audio []float32) (string, error) {
+	result, err := w.TranscribeDetailed(audio)
+	return result.Text, err
+}
+
+// TranscribeDetailed converts audio to text and reports language, timing and segment count
+func (w *Whisper) TranscribeDetailed(audio []float32) (Result, error) {
+	return w.TranscribeContext(context.Background(), audio)
+}
+
+// TranscribeContext is TranscribeDetailed with cancellation. The whisper.cpp
+// Go bindings can only abort before the encoder starts, so a cancellation
+// that arrives later waits for decoding to finish; the result is then
+// discarded and ctx.Err() returned.
+func (w *Whisper) TranscribeContext(ctx context.Context, audio []float32) (Result, error) {
+	return w.TranscribeStream(ctx, audio, nil)
+}
+
+// TranscribeStream is TranscribeContext that also calls onSegment with the
+// text of each segment as whisper decodes it, before the whole result is
+// ready. Segments dropped as non-speech or low confidence are not passed on.
+// onSegment runs on whisper's thread and should return quickly.
+func (w *Whisper) TranscribeStream(ctx context.Context, audio []float32, onSegment func(text string)) (Result, error) {
 	if len(audio) == 0 {
-		return "", nil
+		return Result{}, nil
 	}
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	start := time.Now()
 
-	context, err := w.model.NewContext()
+	w.mu.RLock()
+	configured := w.language
+	w.mu.RUnlock()
+
+	whisperCtx, err := w.model.NewContext()
 	if err != nil {
-		return "", fmt.Errorf("failed to create context: %w", err)
+		return Result{}, fmt.Errorf("failed to create context: %w", err)
+	}
+
+	if w.threads > 0 {
+		if setter, ok := whisperCtx.(threadSetter); ok {
+			setter.SetThreads(uint(w.threads))
+		}
+	}
+	if setter, ok := whisperCtx.(temperatureSetter); ok {
+		setter.SetTemperature(w.temperature)
+		setter.SetTemperatureFallback(w.temperatureInc)
 	}
 
 	// Set language if specified
-	if w.language != "" && w.language != "auto" {
-		if err := context.SetLanguage(w.language); err != nil {
-			return "", fmt.Errorf("failed to set language: %w", err)
+	autoDetect := configured == "" || configured == "auto"
+	if !autoDetect {
+		if err := whisperCtx.SetLanguage(configured); err != nil {
+			return Result{}, fmt.Errorf("failed to set language: %w", err)
 		}
 	}
 
-	// Process audio
-	if err := context.Process(audio, nil, nil); err != nil {
-		return "", fmt.Errorf("failed to process audio: %w", err)
+	// Process audio, aborting before encoding if ctx was cancelled meanwhile
+	encoderBegin := func() bool { return ctx.Err() == nil }
+	var newSegment interface{}
+	if onSegment != nil {
+		newSegment = func(segment WhisperSegment) {
+			// Dropped segments are logged once, when the result is collected
+			if _, low := w.lowConfidence(segment); !low && !IsNonSpeech(segment.GetText()) {
+				onSegment(segment.GetText())
+			}
+		}
+	}
+	if err := whisperCtx.Process(audio, encoderBegin, newSegment); err != nil {
+		if ctx.Err() != nil {
+			return Result{}, ctx.Err()
+		}
+		return Result{}, fmt.Errorf("failed to process audio: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
 	}
 
 	// Get text from all segments
-	var text strings.Builder
+	var segments []string
+	nonSpeech := 0
+	var confidenceSum float32
+	scored := 0
 	for {
-		segment, err := context.NextSegment()
+		segment, err := whisperCtx.NextSegment()
 		if err != nil {
 			break
 		}
-		text.WriteString(segment.GetText())
+		if IsNonSpeech(segment.GetText()) {
+			nonSpeech++
+			continue
+		}
+		if !w.confident(segment) {
+			continue
+		}
+		segments = append(segments, segment.GetText())
+		if confidence, ok := segment.(SegmentConfidence); ok {
+			confidenceSum += confidence.Confidence()
+			scored++
+		}
+	}
+	var confidence float32
+	if scored > 0 {
+		confidence = confidenceSum / float32(scored)
+	}
+
+	language := configured
+	if autoDetect {
+		language = whisperCtx.DetectedLanguage()
+	}
+
+	return Result{
+		Text:       CollapseRepeats(joinSegments(segments, w.normalizeSpacing), w.maxRepeat),
+		Language:   language,
+		Duration:   time.Since(start),
+		Segments:   len(segments),
+		NonSpeech:  nonSpeech,
+		Confidence: confidence,
+	}, nil
+}
+
+// SetNormalizeSpacing controls how segments are joined. By default segments
+// are concatenated exactly as whisper produced them and only the ends of the
+// text are trimmed; when normalize is true each segment is trimmed and
+// segments are separated by a single space. Call before transcribing.
+func (w *Whisper) SetNormalizeSpacing(normalize bool) {
+	w.normalizeSpacing = normalize
+}
+
+// SetMaxRepeat enables collapsing of phrases repeated back to back more than
+// maxRepeat times, see CollapseRepeats. Zero disables it. Call before transcribing.
+func (w *Whisper) SetMaxRepeat(maxRepeat int) {
+	w.maxRepeat = maxRepeat
+}
+
+// SetThreads sets the number of CPU threads used per transcription. Zero
+// keeps the whisper default; values above the number of CPUs are rejected
+// since oversubscribing slows transcription down. Call before transcribing.
+func (w *Whisper) SetThreads(threads int) error {
+	if threads < 0 {
+		return fmt.Errorf("invalid thread count: %d (must be >= 0)", threads)
+	}
+	if cpus := runtime.NumCPU(); threads > cpus {
+		return fmt.Errorf("invalid thread count: %d exceeds the %d available CPUs", threads, cpus)
+	}
+	w.threads = threads
+	return nil
+}
+
+// SetTemperature sets the sampling temperature (0-1) of the first decode and
+// the increment added each time whisper retries a decode that failed its
+// quality checks. Temperature 0 with increment 0 is fully greedy and the
+// most deterministic, which helps against hallucinations on quiet audio.
+// Call before transcribing.
+func (w *Whisper) SetTemperature(temperature, increment float32) error {
+	if temperature < 0 || temperature > 1 {
+		return fmt.Errorf("invalid temperature: %v (must be between 0 and 1)", temperature)
+	}
+	if increment < 0 || increment > 1 {
+		return fmt.Errorf("invalid temperature increment: %v (must be between 0 and 1)", increment)
+	}
+	w.temperature = temperature
+	w.temperatureInc = increment
+	return nil
+}
+
+// SetMinConfidence drops segments whose confidence is below minConfidence
+// (0-1), suppressing low-confidence hallucinations at the cost of sometimes
+// losing quiet speech. Zero disables filtering. Call before transcribing.
+func (w *Whisper) SetMinConfidence(minConfidence float32) {
+	w.minConfidence = minConfidence
+}
+
+// confident reports whether a segment passes the confidence threshold,
+// logging segments that are dropped
+func (w *Whisper) confident(segment WhisperSegment) bool {
+	confidence, ok := w.lowConfidence(segment)
+	if ok {
+		log.Printf("Suppressed low-confidence segment (%.2f): %q", confidence, strings.TrimSpace(segment.GetText()))
+	}
+	return !ok
+}
+
+// lowConfidence reports whether a segment falls below the confidence
+// threshold, and its confidence. Segments that cannot report a confidence
+// are always kept.
+func (w *Whisper) lowConfidence(segment WhisperSegment) (float32, bool) {
+	if w.minConfidence <= 0 {
+		return 0, false
+	}
+	scored, ok := segment.(SegmentConfidence)
+	if !ok {
+		return 0, false
+	}
+	confidence := scored.Confidence()
+	return confidence, confidence < w.minConfidence
+}
+
+// joinSegments combines segment texts into the final transcription
+func joinSegments(segments []string, normalize bool) string {
+	if !normalize {
+		return strings.TrimSpace(strings.Join(segments, ""))
+	}
+
+	parts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if trimmed := strings.TrimSpace(segment); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
 	}
+	return strings.Join(parts, " ")
+}
+
+// SetLanguage changes the language used for subsequent transcriptions.
+// "auto" enables detection. If the model reports its supported languages,
+// other codes are checked against that list.
+func (w *Whisper) SetLanguage(language string) error {
+	if language != "auto" {
+		if supported := w.SupportedLanguages(); supported != nil {
+			found := false
+			for _, code := range supported {
+				if code == language {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("unsupported language %q (supported: auto, %s)", language, strings.Join(supported, ", "))
+			}
+		}
+	}
+
+	w.mu.Lock()
+	w.language = language
+	w.mu.Unlock()
+	return nil
+}
+
+// SupportedLanguages returns the sorted language codes the model supports,
+// or nil if the model cannot report them. English-only models report just "en".
+func (w *Whisper) SupportedLanguages() []string {
+	lister, ok := w.model.(LanguageLister)
+	if !ok {
+		return nil
+	}
+	supported := append([]string(nil), lister.Languages()...)
+	sort.Strings(supported)
+	return supported
+}
 
-	return strings.TrimSpace(text.String()), nil
+// CheckAutoDetect returns ErrAutoDetectUnsupported if the language is
+// "auto" or empty, both of which auto-detect, but the model only supports
+// English, in which case whisper silently transcribes everything as English
+func (w *Whisper) CheckAutoDetect() error {
+	if language := w.Language(); language != "auto" && language != "" {
+		return nil
+	}
+	if supported := w.SupportedLanguages(); len(supported) == 1 && supported[0] == "en" {
+		return ErrAutoDetectUnsupported
+	}
+	return nil
+}
+
+// Language returns the language used for transcription
+func (w *Whisper) Language() string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.language
+}
+
+// warmupSamples is one second of silence at whisper's 16kHz input rate
+const warmupSamples = 16000
+
+// Warmup runs a short silent buffer through the model so lazy initialization
+// happens up front instead of delaying the first real transcription. It
+// returns how long the warm-up took.
+func (w *Whisper) Warmup() (time.Duration, error) {
+	start := time.Now()
+	if _, err := w.Transcribe(make([]float32, warmupSamples)); err != nil {
+		return time.Since(start), fmt.Errorf("warm-up failed: %w", err)
+	}
+	return time.Since(start), nil
 }
 
 // Close releases resources
@@ -71,4 +362,4 @@ func (w *Whisper) Close() error {
 		return w.model.Close()
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -1,7 +1,9 @@
 package transcriber
 
 import (
+	"context"
 	"errors"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -427,4 +429,416 @@ func TestWhisper_ConcurrentAccess(t *testing.T) {
 	if len(mockModel.Contexts) != numGoroutines {
 		t.Errorf("Expected %d contexts for concurrent access, got %d", numGoroutines, len(mockModel.Contexts))
 	}
-}
\ No newline at end of file
+}
+func TestWhisper_TranscribeDetailed_WithMocks(t *testing.T) {
+	tests := []struct {
+		name             string
+		language         string
+		detected         string
+		segments         []string
+		expectedText     string
+		expectedLanguage string
+	}{
+		{
+			name:             "fixed language reported as configured",
+			language:         "en",
+			detected:         "",
+			segments:         []string{" Hello", " world"},
+			expectedText:     "Hello world",
+			expectedLanguage: "en",
+		},
+		{
+			name:             "auto language reports detected language",
+			language:         "auto",
+			detected:         "de",
+			segments:         []string{" Guten Tag"},
+			expectedText:     "Guten Tag",
+			expectedLanguage: "de",
+		},
+		{
+			name:             "no segments",
+			language:         "auto",
+			detected:         "en",
+			segments:         nil,
+			expectedText:     "",
+			expectedLanguage: "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewMockModel()
+			model.NewContextFunc = func() (WhisperContext, error) {
+				ctx := NewMockContext()
+				ctx.Detected = tt.detected
+				for _, text := range tt.segments {
+					ctx.AddSegment(text)
+				}
+				return ctx, nil
+			}
+
+			w := &Whisper{model: model, language: tt.language}
+			result, err := w.TranscribeDetailed([]float32{0.1, 0.2})
+			if err != nil {
+				t.Fatalf("TranscribeDetailed() error = %v", err)
+			}
+
+			if result.Text != tt.expectedText {
+				t.Errorf("Text = %q, want %q", result.Text, tt.expectedText)
+			}
+			if result.Language != tt.expectedLanguage {
+				t.Errorf("Language = %q, want %q", result.Language, tt.expectedLanguage)
+			}
+			if result.Segments != len(tt.segments) {
+				t.Errorf("Segments = %d, want %d", result.Segments, len(tt.segments))
+			}
+			if result.Duration < 0 {
+				t.Errorf("Duration should not be negative, got %v", result.Duration)
+			}
+		})
+	}
+}
+
+func TestWhisper_TranscribeDetailed_Errors(t *testing.T) {
+	model := NewMockModel()
+	model.ShouldFailContext = true
+
+	w := &Whisper{model: model, language: "en"}
+	if _, err := w.TranscribeDetailed([]float32{0.1}); err == nil {
+		t.Error("Expected error when context creation fails")
+	}
+
+	result, err := w.TranscribeDetailed(nil)
+	if err != nil || result != (Result{}) {
+		t.Errorf("Empty audio should return zero result, got %+v, %v", result, err)
+	}
+}
+
+func TestWhisper_TranscribeContext_Cancelled(t *testing.T) {
+	t.Run("cancelled before start", func(t *testing.T) {
+		model := NewMockModel()
+		w := &Whisper{model: model, language: "en"}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err := w.TranscribeContext(ctx, []float32{0.1}); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if len(model.Contexts) != 0 {
+			t.Errorf("Expected no whisper context to be created, got %d", len(model.Contexts))
+		}
+	})
+
+	t.Run("cancelled before encoding", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		mockCtx := NewMockContext()
+		mockCtx.AddSegment("stale")
+
+		model := NewMockModel()
+		model.NewContextFunc = func() (WhisperContext, error) {
+			cancel()
+			return mockCtx, nil
+		}
+		w := &Whisper{model: model, language: "en"}
+
+		result, err := w.TranscribeContext(ctx, []float32{0.1})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if result.Text != "" {
+			t.Errorf("Expected result to be discarded, got %q", result.Text)
+		}
+		if len(mockCtx.ProcessedAudio) != 0 {
+			t.Error("Expected encoding to be aborted")
+		}
+	})
+}
+
+func TestWhisper_Warmup(t *testing.T) {
+	model := NewMockModel()
+	w := &Whisper{model: model, language: "en"}
+
+	if _, err := w.Warmup(); err != nil {
+		t.Fatalf("Warmup() error = %v", err)
+	}
+	if len(model.Contexts) != 1 || len(model.Contexts[0].ProcessedAudio) != 1 {
+		t.Fatal("Expected one warm-up buffer to be processed")
+	}
+	if got := len(model.Contexts[0].ProcessedAudio[0]); got != warmupSamples {
+		t.Errorf("Warm-up processed %d samples, want %d", got, warmupSamples)
+	}
+
+	model.ShouldFailContext = true
+	if _, err := w.Warmup(); err == nil || !strings.Contains(err.Error(), "warm-up failed") {
+		t.Errorf("Expected wrapped warm-up error, got %v", err)
+	}
+}
+
+// multilingualModel is a mock model that reports its supported languages
+type multilingualModel struct {
+	*MockWhisperModel
+	languages []string
+}
+
+func (m *multilingualModel) Languages() []string {
+	return m.languages
+}
+
+func TestWhisper_SetLanguage(t *testing.T) {
+	model := &multilingualModel{MockWhisperModel: NewMockModel(), languages: []string{"fr", "en", "de"}}
+	w := &Whisper{model: model, language: "en"}
+
+	if err := w.SetLanguage("de"); err != nil {
+		t.Fatalf("SetLanguage(de) error = %v", err)
+	}
+	if w.Language() != "de" {
+		t.Errorf("Language() = %q, want de", w.Language())
+	}
+
+	err := w.SetLanguage("xx")
+	if err == nil || !strings.Contains(err.Error(), "supported: auto, de, en, fr") {
+		t.Errorf("Expected error listing supported languages, got %v", err)
+	}
+	if w.Language() != "de" {
+		t.Errorf("Failed SetLanguage should keep %q, got %q", "de", w.Language())
+	}
+
+	if err := w.SetLanguage("auto"); err != nil {
+		t.Errorf("SetLanguage(auto) error = %v", err)
+	}
+
+	// Subsequent transcriptions use the new language
+	if err := w.SetLanguage("fr"); err != nil {
+		t.Fatalf("SetLanguage(fr) error = %v", err)
+	}
+	if _, err := w.Transcribe([]float32{0.1}); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if got := model.Contexts[0].Language; got != "fr" {
+		t.Errorf("Context language = %q, want fr", got)
+	}
+}
+
+func TestWhisper_SetLanguage_UnknownSupport(t *testing.T) {
+	// Models that cannot list languages accept any code
+	w := &Whisper{model: NewMockModel(), language: "en"}
+	if err := w.SetLanguage("xx"); err != nil {
+		t.Errorf("SetLanguage() error = %v", err)
+	}
+}
+
+func TestWhisper_SupportedLanguages(t *testing.T) {
+	w := &Whisper{model: &multilingualModel{MockWhisperModel: NewMockModel(), languages: []string{"fr", "en", "de"}}}
+	if got := strings.Join(w.SupportedLanguages(), ","); got != "de,en,fr" {
+		t.Errorf("SupportedLanguages() = %q, want de,en,fr", got)
+	}
+
+	w = &Whisper{model: NewMockModel()}
+	if got := w.SupportedLanguages(); got != nil {
+		t.Errorf("SupportedLanguages() = %v, want nil for a model that cannot list languages", got)
+	}
+}
+
+func TestWhisper_CheckAutoDetect(t *testing.T) {
+	tests := []struct {
+		name      string
+		language  string
+		languages []string
+		wantErr   bool
+	}{
+		{"auto with multilingual model", "auto", []string{"en", "de"}, false},
+		{"auto with english-only model", "auto", []string{"en"}, true},
+		{"empty language with english-only model", "", []string{"en"}, true},
+		{"fixed language with english-only model", "en", []string{"en"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Whisper{model: &multilingualModel{MockWhisperModel: NewMockModel(), languages: tt.languages}, language: tt.language}
+			err := w.CheckAutoDetect()
+			if got := errors.Is(err, ErrAutoDetectUnsupported); got != tt.wantErr {
+				t.Errorf("CheckAutoDetect() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Models that cannot list languages are given the benefit of the doubt
+	w := &Whisper{model: NewMockModel(), language: "auto"}
+	if err := w.CheckAutoDetect(); err != nil {
+		t.Errorf("CheckAutoDetect() = %v, want nil", err)
+	}
+}
+
+func TestJoinSegments(t *testing.T) {
+	tests := []struct {
+		name      string
+		segments  []string
+		normalize bool
+		want      string
+	}{
+		{"default keeps inner spacing", []string{" Hello ", " world"}, false, "Hello  world"},
+		{"default keeps joined words", []string{"Hel", "lo"}, false, "Hello"},
+		{"default no segments", nil, false, ""},
+		{"normalize whisper leading spaces", []string{" Hello", " world."}, true, "Hello world."},
+		{"normalize missing space between segments", []string{"Hello", "world."}, true, "Hello world."},
+		{"normalize double spaces at boundaries", []string{" Hello ", "  world. "}, true, "Hello world."},
+		{"normalize skips empty segments", []string{" Hello", "  ", "", " world"}, true, "Hello world"},
+		{"normalize no segments", nil, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := joinSegments(tt.segments, tt.normalize); got != tt.want {
+				t.Errorf("joinSegments() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhisper_SetMinConfidence(t *testing.T) {
+	tests := []struct {
+		name          string
+		minConfidence  float32
+		want           string
+		wantSegments   int
+		wantConfidence float32
+	}{
+		{"disabled keeps everything", 0, "Hello world. Thanks for watching!", 3, 1.9 / 3},
+		{"drops low-confidence segments", 0.5, "Hello world.", 2, 0.85},
+		{"strict threshold drops all", 0.99, "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewMockModel()
+			model.NewContextFunc = func() (WhisperContext, error) {
+				ctx := NewMockContext()
+				ctx.Segments = []*MockWhisperSegment{
+					{Text: " Hello", Prob: 0.9},
+					{Text: " world.", Prob: 0.8},
+					{Text: " Thanks for watching!", Prob: 0.2},
+				}
+				return ctx, nil
+			}
+
+			w := &Whisper{model: model, language: "en"}
+			w.SetMinConfidence(tt.minConfidence)
+
+			result, err := w.TranscribeDetailed([]float32{0.1})
+			if err != nil {
+				t.Fatalf("TranscribeDetailed() error = %v", err)
+			}
+			if result.Text != tt.want {
+				t.Errorf("Text = %q, want %q", result.Text, tt.want)
+			}
+			if result.Segments != tt.wantSegments {
+				t.Errorf("Segments = %d, want %d", result.Segments, tt.wantSegments)
+			}
+			if diff := result.Confidence - tt.wantConfidence; diff > 1e-6 || diff < -1e-6 {
+				t.Errorf("Confidence = %v, want %v", result.Confidence, tt.wantConfidence)
+			}
+		})
+	}
+}
+
+func TestWhisper_NonSpeechSegments(t *testing.T) {
+	model := NewMockModel()
+	model.NewContextFunc = func() (WhisperContext, error) {
+		ctx := NewMockContext()
+		ctx.Segments = []*MockWhisperSegment{
+			{Text: " [BLANK_AUDIO]"},
+			{Text: " Hello"},
+			{Text: " (wind blowing)"},
+		}
+		return ctx, nil
+	}
+
+	w := &Whisper{model: model, language: "en"}
+	result, err := w.TranscribeDetailed([]float32{0.1})
+	if err != nil {
+		t.Fatalf("TranscribeDetailed() error = %v", err)
+	}
+	if result.Text != "Hello" {
+		t.Errorf("Text = %q, want %q", result.Text, "Hello")
+	}
+	if result.NonSpeech != 2 {
+		t.Errorf("NonSpeech = %d, want 2", result.NonSpeech)
+	}
+}
+
+func TestWhisper_TranscribeStream(t *testing.T) {
+	model := NewMockModel()
+	model.NewContextFunc = func() (WhisperContext, error) {
+		ctx := NewMockContext()
+		ctx.Segments = []*MockWhisperSegment{
+			{Text: " Hello", Prob: 0.9},
+			{Text: " [BLANK_AUDIO]", Prob: 0.9},
+			{Text: " uh", Prob: 0.1},
+			{Text: " world.", Prob: 0.8},
+		}
+		return ctx, nil
+	}
+
+	w := &Whisper{model: model, language: "en"}
+	w.SetMinConfidence(0.5)
+
+	var streamed []string
+	result, err := w.TranscribeStream(context.Background(), []float32{0.1}, func(text string) {
+		streamed = append(streamed, text)
+	})
+	if err != nil {
+		t.Fatalf("TranscribeStream() error = %v", err)
+	}
+	if len(streamed) != 2 || streamed[0] != " Hello" || streamed[1] != " world." {
+		t.Errorf("Streamed segments = %q, want [\" Hello\" \" world.\"]", streamed)
+	}
+	if result.Text != "Hello world." {
+		t.Errorf("Text = %q, want %q", result.Text, "Hello world.")
+	}
+}
+
+func TestWhisper_SetThreads(t *testing.T) {
+	model := NewMockModel()
+	w := &Whisper{model: model, language: "en"}
+
+	if err := w.SetThreads(-1); err == nil {
+		t.Error("Expected error for negative thread count")
+	}
+	if err := w.SetThreads(runtime.NumCPU() + 1); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("Expected error for more threads than CPUs, got %v", err)
+	}
+	if err := w.SetThreads(1); err != nil {
+		t.Fatalf("SetThreads(1) error = %v", err)
+	}
+
+	if _, err := w.Transcribe([]float32{0.1}); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if got := model.Contexts[0].Threads; got != 1 {
+		t.Errorf("Context threads = %d, want 1", got)
+	}
+}
+
+func TestWhisper_SetTemperature(t *testing.T) {
+	model := NewMockModel()
+	w := &Whisper{model: model, language: "en"}
+
+	for _, tt := range []struct{ temperature, increment float32 }{
+		{-0.1, 0}, {1.5, 0}, {0, -0.2}, {0, 2},
+	} {
+		if err := w.SetTemperature(tt.temperature, tt.increment); err == nil {
+			t.Errorf("SetTemperature(%v, %v) expected error", tt.temperature, tt.increment)
+		}
+	}
+	if err := w.SetTemperature(0.4, 0.1); err != nil {
+		t.Fatalf("SetTemperature(0.4, 0.1) error = %v", err)
+	}
+
+	if _, err := w.Transcribe([]float32{0.1}); err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if got := model.Contexts[0]; got.Temperature != 0.4 || got.TemperatureInc != 0.1 {
+		t.Errorf("Context temperature = %v (+%v), want 0.4 (+0.1)", got.Temperature, got.TemperatureInc)
+	}
+}
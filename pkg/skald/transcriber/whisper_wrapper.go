@@ -1,6 +1,8 @@
 package transcriber
 
 import (
+	"strings"
+
 	whisper "github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
 )
 
@@ -21,6 +23,13 @@ func (w *WhisperModelWrapper) Close() error {
 	return w.model.Close()
 }
 
+func (w *WhisperModelWrapper) Languages() []string {
+	if !w.model.IsMultilingual() {
+		return []string{"en"}
+	}
+	return w.model.Languages()
+}
+
 // WhisperContextWrapper wraps the actual whisper context
 type WhisperContextWrapper struct {
 	context whisper.Context
@@ -43,12 +52,22 @@ func (w *WhisperContextWrapper) Process(audio []float32, cb1, cb2 interface{}) e
 		if sc, ok := cb1.(whisper.SegmentCallback); ok {
 			segmentCallback = sc
 		}
+		// An encoder begin callback returning false aborts processing
+		if eb, ok := cb1.(func() bool); ok {
+			encoderBeginCallback = eb
+		}
 	}
 	
 	if cb2 != nil {
 		if pc, ok := cb2.(whisper.ProgressCallback); ok {
 			progressCallback = pc
 		}
+		// A segment callback here receives wrapped segments as they are decoded
+		if sc, ok := cb2.(func(WhisperSegment)); ok {
+			segmentCallback = func(segment whisper.Segment) {
+				sc(&WhisperSegmentWrapper{segment: segment})
+			}
+		}
 	}
 	
 	return w.context.Process(audio, encoderBeginCallback, segmentCallback, progressCallback)
@@ -62,6 +81,22 @@ func (w *WhisperContextWrapper) NextSegment() (WhisperSegment, error) {
 	return &WhisperSegmentWrapper{segment: segment}, nil
 }
 
+func (w *WhisperContextWrapper) DetectedLanguage() string {
+	return w.context.DetectedLanguage()
+}
+
+func (w *WhisperContextWrapper) SetThreads(threads uint) {
+	w.context.SetThreads(threads)
+}
+
+func (w *WhisperContextWrapper) SetTemperature(temperature float32) {
+	w.context.SetTemperature(temperature)
+}
+
+func (w *WhisperContextWrapper) SetTemperatureFallback(increment float32) {
+	w.context.SetTemperatureFallback(increment)
+}
+
 // WhisperSegmentWrapper wraps the actual whisper segment
 type WhisperSegmentWrapper struct {
 	segment whisper.Segment
@@ -71,6 +106,24 @@ func (w *WhisperSegmentWrapper) GetText() string {
 	return w.segment.Text
 }
 
+// Confidence returns the mean probability of the segment's text tokens.
+// Special tokens such as timestamps ([_TT_...]) are ignored.
+func (w *WhisperSegmentWrapper) Confidence() float32 {
+	var sum float32
+	count := 0
+	for _, token := range w.segment.Tokens {
+		if strings.HasPrefix(token.Text, "[_") || strings.HasPrefix(token.Text, "<|") {
+			continue
+		}
+		sum += token.P
+		count++
+	}
+	if count == 0 {
+		return 1
+	}
+	return sum / float32(count)
+}
+
 // DefaultWhisperModelFactory creates real whisper models
 type DefaultWhisperModelFactory struct{}
 